@@ -6,7 +6,6 @@ import (
 	"math"
 	"reflect"
 	"regexp"
-	"runtime"
 	"strings"
 	"sync/atomic"
 
@@ -22,6 +21,9 @@ type Assertions struct {
 	must bool
 
 	desc []string
+
+	// compareOptions of the failed Eq or Equal, for the report
+	compareOptions []CompareOption
 }
 
 // Desc returns a clone with the format description. The description will be printed before the error message.
@@ -41,11 +43,16 @@ func (as Assertions) Must() Assertions {
 // Eq asserts that x equals y when converted to the same type, such as compare float 1.0 and integer 1 .
 // For strict value and type comparison use Assertions.Equal .
 // For how comparison works, see [utils.SmartCompare] .
-func (as Assertions) Eq(x, y interface{}) {
+// Use the opts to relax the comparison, such as [IgnoreFields] and [FloatTolerance].
+func (as Assertions) Eq(x, y interface{}, opts ...CompareOption) {
 	as.Helper()
 	if utils.SmartCompare(x, y) == 0 {
 		return
 	}
+	if len(opts) > 0 && compareWithOptions(x, y, false, opts) {
+		return
+	}
+	as.compareOptions = opts
 	as.err(AssertionEq, x, y)
 }
 
@@ -69,11 +76,16 @@ func (as Assertions) Neq(x, y interface{}) {
 
 // Equal asserts that x equals y.
 // For loose type comparison use Assertions.Eq, such as compare float 1.0 and integer 1 .
-func (as Assertions) Equal(x, y interface{}) {
+// Use the opts to relax the comparison, such as [IgnoreFields] and [FloatTolerance].
+func (as Assertions) Equal(x, y interface{}, opts ...CompareOption) {
 	as.Helper()
 	if utils.Compare(x, y) == 0 {
 		return
 	}
+	if len(opts) > 0 && compareWithOptions(x, y, true, opts) {
+		return
+	}
+	as.compareOptions = opts
 	as.err(AssertionEq, x, y)
 }
 
@@ -345,16 +357,22 @@ func (as Assertions) err(t AssertionErrType, details ...interface{}) {
 		}
 	}
 
-	// TODO: we should take advantage of the Helper function
-	_, f, l, _ := runtime.Caller(2)
+	f, l, method := callSite()
+	source, args := callSource(f, l, method)
 	c := &AssertionCtx{
 		Type:    t,
 		Details: details,
 		File:    f,
 		Line:    l,
+		Source:  source,
+		Args:    args,
+
+		CompareOptions: as.compareOptions,
 	}
 
-	as.Logf("%s", as.ErrorHandler.Report(c))
+	report := as.ErrorHandler.Report(c)
+	as.Logf("%s", report)
+	as.emitEvent(c, report)
 
 	if as.must {
 		as.FailNow()
@@ -0,0 +1,65 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ErrAs asserts that an error in the chain of err matches target via [errors.As],
+// the target must be a non-nil pointer to a type that implements error or to any interface type.
+func (as Assertions) ErrAs(err error, target interface{}) {
+	as.Helper()
+	if errors.As(err, target) {
+		return
+	}
+	as.err(AssertionErrAs, err, reflect.TypeOf(target).Elem())
+}
+
+// ErrContains asserts that err is not nil and its message contains substr
+func (as Assertions) ErrContains(err error, substr string) {
+	as.Helper()
+	if err != nil && strings.Contains(err.Error(), substr) {
+		return
+	}
+	as.err(AssertionErrContains, err, substr)
+}
+
+// ErrRegex asserts that err is not nil and its message matches the regex pattern
+func (as Assertions) ErrRegex(err error, pattern string) {
+	as.Helper()
+	if err != nil && regexp.MustCompile(pattern).MatchString(err.Error()) {
+		return
+	}
+	as.err(AssertionErrRegex, err, pattern)
+}
+
+// errorChain formats the wrapped chain of err as a tree with the type of each error,
+// the children of the errors created by [errors.Join] are indented under it.
+func errorChain(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return strings.Join(errorTree(err, 0, nil), "\n")
+}
+
+func errorTree(err error, depth int, lines []string) []string {
+	lines = append(lines, fmt.Sprintf("%s%T %q", strings.Repeat("    ", depth), err, err.Error()))
+
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			if child != nil {
+				lines = errorTree(child, depth+1, lines)
+			}
+		}
+	case interface{ Unwrap() error }:
+		if child := e.Unwrap(); child != nil {
+			lines = errorTree(child, depth+1, lines)
+		}
+	}
+
+	return lines
+}
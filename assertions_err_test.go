@@ -0,0 +1,69 @@
+package got_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+type codeErr struct{ code int }
+
+func (e codeErr) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestErrAssertions(t *testing.T) {
+	g := setup(t)
+
+	err := fmt.Errorf("wrap: %w", errors.Join(errors.New("a"), codeErr{404}))
+
+	var ce codeErr
+	g.ErrAs(err, &ce)
+	g.Eq(ce.code, 404)
+	g.ErrContains(err, "code 404")
+	g.ErrRegex(err, `^wrap: a\ncode \d+$`)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	var pe *fs.PathError
+	gm.ErrAs(err, &pe)
+	m.check(`gm.ErrAs(err, &pe)
+
+⦗error chain⦘
+
+*fmt.wrapError "wrap: a\ncode 404"
+    *errors.joinError "a\ncode 404"
+        *errors.errorString "a"
+        got_test.codeErr "code 404"
+
+⦗should have an error assignable to⦘
+
+*fs.PathError`)
+
+	gm.ErrContains(os.ErrNotExist, "z")
+	m.check(`gm.ErrContains(os.ErrNotExist, "z")
+ ⦗error chain⦘ *errors.errorString "file does not exist" ⦗should contain⦘ "z"`)
+
+	gm.ErrContains(nil, "x")
+	m.check(` ⦗error chain⦘ <nil> ⦗should contain⦘ "x"`)
+
+	gm.ErrRegex(nil, `x`)
+	m.check(` ⦗error chain⦘ <nil> ⦗should match⦘ "x"`)
+
+	gm.ErrAs(errors.Join(nil, errors.New("a")), &pe)
+	m.check(`gm.ErrAs(errors.Join(nil, errors.New("a")), &pe)
+
+⦗error chain⦘
+
+*errors.joinError "a"
+    *errors.errorString "a"
+
+⦗should have an error assignable to⦘
+
+*fs.PathError`)
+}
@@ -2,11 +2,16 @@ package got
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/ysmood/gop"
 	"github.com/ysmood/got/lib/diff"
+	"github.com/ysmood/got/lib/structdiff"
 )
 
 // AssertionErrType enum
@@ -65,14 +70,74 @@ const (
 	AssertionCount
 	// AssertionSnapshot type
 	AssertionSnapshot
+	// AssertionSnapshotMissing type
+	AssertionSnapshotMissing
+	// AssertionSnapshotUnused type
+	AssertionSnapshotUnused
+	// AssertionEventually type
+	AssertionEventually
+	// AssertionConsistently type
+	AssertionConsistently
+	// AssertionMatch type
+	AssertionMatch
+	// AssertionElementsMatch type
+	AssertionElementsMatch
+	// AssertionJSONEq type
+	AssertionJSONEq
+	// AssertionJSONInvalid type
+	AssertionJSONInvalid
+	// AssertionErrAs type
+	AssertionErrAs
+	// AssertionErrContains type
+	AssertionErrContains
+	// AssertionErrRegex type
+	AssertionErrRegex
+	// AssertionPanicWith type
+	AssertionPanicWith
+	// AssertionPanicMatch type
+	AssertionPanicMatch
+	// AssertionNotPanic type
+	AssertionNotPanic
+	// AssertionGroup type
+	AssertionGroup
+
+	// AssertionNotList type
+	AssertionNotList
 )
 
+var assertionErrTypeNames = []string{
+	"Eq", "NeqSame", "Neq", "Gt", "Gte", "Lt", "Lte", "InDelta", "True", "False",
+	"Nil", "NoArgs", "NotNil", "NotNilable", "NotNilableNil", "Zero", "NotZero", "Regex", "Has", "Len",
+	"Err", "Panic", "IsInChain", "IsKind", "Count", "Snapshot", "SnapshotMissing", "SnapshotUnused",
+	"Eventually", "Consistently", "Match", "ElementsMatch", "JSONEq", "JSONInvalid",
+	"ErrAs", "ErrContains", "ErrRegex", "PanicWith", "PanicMatch", "NotPanic", "Group",
+	"NotList",
+}
+
+// String returns the name of the type without the "Assertion" prefix, such as "Eq"
+func (t AssertionErrType) String() string {
+	if t < 0 || int(t) >= len(assertionErrTypeNames) {
+		return fmt.Sprintf("AssertionErrType(%d)", int(t))
+	}
+	return assertionErrTypeNames[t]
+}
+
 // AssertionCtx holds the context of an assertion
 type AssertionCtx struct {
 	Type    AssertionErrType
 	Details []interface{}
 	File    string
 	Line    int
+
+	// Source is the source code of the assertion call, such as `g.Eq(user.Name, "bob")`,
+	// it's empty if the source file is not available.
+	Source string
+	// Args is the source code of each argument of the assertion call
+	Args []string
+
+	// CompareOptions are the options passed to [Assertions.Eq] or [Assertions.Equal],
+	// the differences they ignore should be excluded from the report.
+	CompareOptions []CompareOption
 }
 
 // AssertionError handler
@@ -91,7 +156,17 @@ func (ae AssertionErrorReport) Report(ac *AssertionCtx) string {
 }
 
 type defaultAssertionError struct {
-	fns map[AssertionErrType]func(details ...interface{}) string
+	eq     func(opts []CompareOption, details ...interface{}) string
+	fns    map[AssertionErrType]func(details ...interface{}) string
+	format func(v interface{}) string
+}
+
+// labeledTypes are the types whose first detail is the value of the first argument of the assertion call
+var labeledTypes = map[AssertionErrType]bool{
+	AssertionEq: true, AssertionNeqSame: true, AssertionNeq: true,
+	AssertionGt: true, AssertionGte: true, AssertionLt: true, AssertionLte: true, AssertionInDelta: true,
+	AssertionZero: true, AssertionNotZero: true, AssertionHas: true,
+	AssertionIsInChain: true, AssertionIsKind: true, AssertionElementsMatch: true,
 }
 
 // NewDefaultAssertionError handler
@@ -104,25 +179,39 @@ func NewDefaultAssertionError(maxDepth int, theme gop.Theme, diffTheme diff.Them
 		return " " + gop.Stylize("⦗"+s+"⦘", theme(gop.Error)) + " "
 	}
 
-	fns := map[AssertionErrType]func(details ...interface{}) string{
-		AssertionEq: func(details ...interface{}) string {
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-			defer cancel()
+	eq := func(opts []CompareOption, details ...interface{}) string {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
 
-			x := f(details[0])
-			y := f(details[1])
+		x := f(details[0])
+		y := f(details[1])
 
-			if diffTheme == nil {
-				return j(x, k("not =="), y)
-			}
+		if diffTheme == nil {
+			return j(x, k("not =="), y)
+		}
 
-			if hasNewline(x, y) {
-				df := diff.Format(diff.Tokenize(ctx, gop.StripANSI(x), gop.StripANSI(y)), diffTheme)
-				return j(x, k("not =="), y, df)
+		if structural(details[0], details[1]) {
+			changes := structdiff.Compare(details[0], details[1])
+			if len(opts) > 0 {
+				changes = filterChanges(changes, opts)
 			}
+			if len(changes) > 0 {
+				return j(x, k("not =="), y, structdiff.Format(changes, diffTheme))
+			}
+		}
 
-			dx, dy := diff.TokenizeLine(ctx, gop.StripANSI(x), gop.StripANSI(y))
-			return diff.Format(dx, diffTheme) + k("not ==") + diff.Format(dy, diffTheme)
+		if hasNewline(x, y) {
+			df := diff.Format(diff.Tokenize(ctx, gop.StripANSI(x), gop.StripANSI(y)), diffTheme)
+			return j(x, k("not =="), y, df)
+		}
+
+		dx, dy := diff.TokenizeLine(ctx, gop.StripANSI(x), gop.StripANSI(y))
+		return diff.Format(dx, diffTheme) + k("not ==") + diff.Format(dy, diffTheme)
+	}
+
+	fns := map[AssertionErrType]func(details ...interface{}) string{
+		AssertionEq: func(details ...interface{}) string {
+			return eq(nil, details...)
 		},
 		AssertionNeqSame: func(details ...interface{}) string {
 			x := f(details[0])
@@ -229,14 +318,170 @@ func NewDefaultAssertionError(maxDepth int, theme gop.Theme, diffTheme diff.Them
 			count := f(details[1])
 			return k("should count") + n + k("times, but got") + count
 		},
+		AssertionSnapshot: func(details ...interface{}) string {
+			path := details[0].(string)
+			x := details[1].(string)
+			y := details[2].(string)
+
+			header := k("snapshot") + path + k("mismatch")
+			hint := k(`run the test with the "-got.update" flag or the "GOT_UPDATE=1" env to update it`)
+
+			if diffTheme == nil {
+				return j(header, x, k("not =="), y, hint)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+
+			df := diff.Format(diff.Tokenize(ctx, y, x), diffTheme)
+			return j(header, strings.TrimRight(df, "\n"), hint)
+		},
+		AssertionSnapshotMissing: func(details ...interface{}) string {
+			path := details[0].(string)
+			return k("snapshot") + path + k(`doesn't exist, run the test with the "-got.update" flag to create it`)
+		},
+		AssertionSnapshotUnused: func(details ...interface{}) string {
+			path := details[0].(string)
+			return k("snapshot") + path + k("is unused, remove it or run the test without the strict mode")
+		},
+		AssertionEventually: func(details ...interface{}) string {
+			header := k("should pass within") + fmt.Sprint(details[0]) + k(fmt.Sprintf("but failed %d attempts, the last one:", details[1]))
+			return j(header, details[2].(string))
+		},
+		AssertionConsistently: func(details ...interface{}) string {
+			header := k("should keep passing during") + fmt.Sprint(details[0]) + k(fmt.Sprintf("but failed at attempt %d:", details[1]))
+			return j(header, details[2].(string))
+		},
+		AssertionMatch: func(details ...interface{}) string {
+			path := details[0].(string)
+			pattern := f(details[2])
+			if details[3].(bool) {
+				return j(path, k("is missing, it should match"), pattern)
+			}
+			x := f(details[1])
+			return j(path, k("value"), x, k("should match"), pattern)
+		},
+		AssertionElementsMatch: func(details ...interface{}) string {
+			x := f(details[0])
+			y := f(details[1])
+			onlyX := details[2].([]interface{})
+			onlyY := details[3].([]interface{})
+			counts := details[4].([]elementCount)
+
+			list := []string{x, k("should match the elements of"), y}
+			if len(onlyX) > 0 {
+				list = append(list, k("only in the former"), f(onlyX))
+			}
+			if len(onlyY) > 0 {
+				list = append(list, k("only in the latter"), f(onlyY))
+			}
+			for _, c := range counts {
+				list = append(list, k("the count of")+f(c.val)+k("is")+f(c.x)+k("but should be")+f(c.y))
+			}
+			return j(list...)
+		},
+		AssertionJSONEq: func(details ...interface{}) string {
+			js := func(v interface{}) string {
+				b, _ := json.Marshal(v) // the v is decoded from json, it won't fail
+				return string(b)
+			}
+
+			lines := []string{strings.Trim(k("json documents are not equal:"), " ")}
+			for _, c := range details[0].([]jsonChange) {
+				p := c.pointer
+				if p == "" {
+					p = "(root)"
+				}
+
+				switch {
+				case c.missing:
+					lines = append(lines, p+k("is missing, it should be")+js(c.expected))
+				case c.unexpected:
+					lines = append(lines, p+k("is unexpected")+js(c.actual))
+				default:
+					lines = append(lines, p+k("value")+js(c.actual)+k("should be")+js(c.expected))
+				}
+			}
+			return "\n" + strings.Join(lines, "\n")
+		},
+		AssertionJSONInvalid: func(details ...interface{}) string {
+			return k("the "+details[0].(string)+" json is invalid:") + details[1].(error).Error()
+		},
+		AssertionErrAs: func(details ...interface{}) string {
+			e, _ := details[0].(error)
+			chain := errorChain(e)
+			return j(k("error chain"), chain, k("should have an error assignable to"), fmt.Sprint(details[1]))
+		},
+		AssertionErrContains: func(details ...interface{}) string {
+			e, _ := details[0].(error)
+			chain := errorChain(e)
+			return j(k("error chain"), chain, k("should contain"), f(details[1]))
+		},
+		AssertionErrRegex: func(details ...interface{}) string {
+			e, _ := details[0].(error)
+			chain := errorChain(e)
+			return j(k("error chain"), chain, k("should match"), f(details[1]))
+		},
+		AssertionPanicWith: func(details ...interface{}) string {
+			val := f(details[0])
+			expected := f(details[1])
+			stack := details[2].(string)
+			return j(k("panic value"), val, k("should be"), expected, stack)
+		},
+		AssertionPanicMatch: func(details ...interface{}) string {
+			val := f(details[0])
+			pattern := f(details[1])
+			stack := details[2].(string)
+			return j(k("panic value"), val, k("should match"), pattern, stack)
+		},
+		AssertionNotPanic: func(details ...interface{}) string {
+			val := f(details[0])
+			stack := details[1].(string)
+			return j(k("should not panic, but panicked with"), val, stack)
+		},
+		AssertionGroup: func(details ...interface{}) string {
+			header := k(fmt.Sprintf("%d assertions failed in the group:", details[0]))
+			return j(header, details[1].(string))
+		},
+		AssertionNotList: func(details ...interface{}) string {
+			return j(k("the "+details[0].(string)+" should be a slice or an array, but got"), f(details[1]))
+		},
 	}
 
-	return &defaultAssertionError{fns: fns}
+	return &defaultAssertionError{eq: eq, fns: fns, format: f}
 }
 
 // Report interface
 func (ae *defaultAssertionError) Report(ac *AssertionCtx) string {
-	return ae.fns[ac.Type](ac.Details...)
+	if ac.Type == AssertionEq && len(ac.CompareOptions) > 0 {
+		return ae.source(ac) + ae.eq(ac.CompareOptions, ac.Details...)
+	}
+	return ae.source(ac) + ae.fns[ac.Type](ac.Details...)
+}
+
+// source returns the source code of the assertion call as the header of the report,
+// if the first argument is an expression, its value will be labeled with it, such as `user.Name = "alice"`.
+// It's empty if all the arguments are literal values, because the report already shows them.
+func (ae *defaultAssertionError) source(ac *AssertionCtx) string {
+	literal := true
+	for _, arg := range ac.Args {
+		if e, err := parser.ParseExpr(arg); err != nil || !isLiteral(e) {
+			literal = false
+		}
+	}
+	if ac.Source == "" || literal {
+		return ""
+	}
+
+	header := ac.Source
+	if labeledTypes[ac.Type] {
+		if e, _ := parser.ParseExpr(ac.Args[0]); !isLiteral(e) {
+			if v := ae.format(ac.Details[0]); !hasNewline(v) {
+				header += "\n" + ac.Args[0] + " = " + v
+			}
+		}
+	}
+	return header + "\n"
 }
 
 func j(args ...string) string {
@@ -249,6 +494,24 @@ func j(args ...string) string {
 	return strings.Join(args, "")
 }
 
+// structural returns true if x and y are composite values of the same type that can be diffed via [structdiff]
+func structural(x, y interface{}) bool {
+	if x == nil || y == nil || reflect.TypeOf(x) != reflect.TypeOf(y) {
+		return false
+	}
+
+	t := reflect.TypeOf(x)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
 func hasNewline(args ...string) bool {
 	for _, arg := range args {
 		if strings.Contains(arg, "\n") {
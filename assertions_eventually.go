@@ -0,0 +1,182 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Eventually runs fn every interval until all the assertions in it pass.
+// If they still fail after the timeout, only the report of the last attempt will be logged.
+// The [Utils.Context] of the G in fn is canceled when the attempt is still running after the timeout,
+// or one interval if it's later, then the attempt is reported as a failure after fn returns.
+// So use the context for the blocking operations in fn, such as http requests, to not hang the test.
+// The attempt is always waited, fn won't keep running after Eventually returns.
+// The cleanups registered in fn run at the end of each attempt.
+func (as Assertions) Eventually(timeout, interval time.Duration, fn func(g G)) {
+	as.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for n := 1; ; n++ {
+		a := as.attempt(fn, attemptDeadline(deadline, interval))
+
+		// the pass of a canceled fn is not trustworthy, the failures of fn are more informative than the timeout
+		if a.late && !a.Failed() {
+			a.Logf("[timeout] the attempt was still running after the deadline, its context is canceled")
+			a.Fail()
+		}
+
+		if !a.Failed() {
+			return
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			as.err(AssertionEventually, timeout, n, a.report())
+			return
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// Consistently runs fn every interval during the duration, all the assertions in it should keep passing.
+// It stops at the first failed attempt and logs its report.
+// Like [Assertions.Eventually], the context of an attempt is canceled if it's still running after the duration,
+// but only the failed assertions in fn fail the attempt.
+// The cleanups registered in fn run at the end of each attempt.
+func (as Assertions) Consistently(duration, interval time.Duration, fn func(g G)) {
+	as.Helper()
+
+	deadline := time.Now().Add(duration)
+	for n := 1; ; n++ {
+		a := as.attempt(fn, attemptDeadline(deadline, interval))
+		if a.Failed() {
+			as.err(AssertionConsistently, duration, n, a.report())
+			return
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// attemptDeadline gives the attempt at least one interval to finish
+func attemptDeadline(deadline time.Time, interval time.Duration) time.Time {
+	if earliest := time.Now().Add(interval); earliest.After(deadline) {
+		return earliest
+	}
+	return deadline
+}
+
+// attempt runs fn in a new goroutine with a G that records the failures instead of reporting them to the test.
+// If the deadline isn't zero and fn is still running after it, the context of the G will be canceled
+// and the attempt is marked late. It always waits for fn to return, so fn won't outlive the attempt.
+func (as Assertions) attempt(fn func(g G), deadline time.Time) *attempt {
+	a := &attempt{Testable: as.Testable, ctx: context.Background()}
+
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		a.ctx, cancel = context.WithDeadline(a.ctx, deadline)
+		defer cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer a.cleanup()
+		defer func() {
+			if err := recover(); err != nil {
+				a.Logf("[panic] %v\n%s", err, debug.Stack())
+				a.Fail()
+			}
+		}()
+
+		g := newG(a)
+		g.ErrorHandler = as.ErrorHandler
+		fn(g)
+	}()
+
+	<-done
+	a.late = a.ctx.Err() != nil
+
+	return a
+}
+
+var _ Testable = &attempt{}
+
+// attempt records the failures and logs of the assertions, it shares the name and helper of the parent test.
+type attempt struct {
+	Testable
+
+	// ctx is the parent of the contexts created by the G of the attempt
+	ctx context.Context
+	// late is true if fn returned after the deadline of the attempt
+	late bool
+
+	lock     sync.Mutex
+	failed   bool
+	skipped  bool
+	logs     []string
+	cleanups []func()
+}
+
+func (a *attempt) Failed() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.failed
+}
+
+func (a *attempt) Fail() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.failed = true
+}
+
+func (a *attempt) FailNow() {
+	a.Fail()
+	runtime.Goexit()
+}
+
+func (a *attempt) Skipped() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.skipped
+}
+
+func (a *attempt) SkipNow() {
+	a.lock.Lock()
+	a.skipped = true
+	a.lock.Unlock()
+	runtime.Goexit()
+}
+
+func (a *attempt) Logf(format string, args ...interface{}) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.logs = append(a.logs, fmt.Sprintf(format, args...))
+}
+
+func (a *attempt) Cleanup(f func()) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.cleanups = append([]func(){f}, a.cleanups...)
+}
+
+func (a *attempt) cleanup() {
+	for _, f := range a.cleanups {
+		f()
+	}
+}
+
+func (a *attempt) report() string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return strings.Join(a.logs, "\n")
+}
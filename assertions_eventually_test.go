@@ -0,0 +1,93 @@
+package got_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+func TestEventually(t *testing.T) {
+	g := setup(t)
+
+	count := int64(0)
+	g.Eventually(time.Second, time.Millisecond, func(g got.G) {
+		g.Gt(atomic.AddInt64(&count, 1), 2)
+	})
+	g.Eq(count, 3)
+
+	cleaned := false
+	g.Eventually(time.Second, time.Millisecond, func(g got.G) {
+		g.Cleanup(func() { cleaned = true })
+		g.False(g.Skipped())
+		g.Skip()
+	})
+	g.True(cleaned)
+
+	count = 0
+	g.Consistently(10*time.Millisecond, time.Millisecond, func(g got.G) {
+		g.Lt(atomic.AddInt64(&count, 1), 1000)
+	})
+	g.Gt(count, 1)
+}
+
+func TestEventuallyErr(t *testing.T) {
+	g := setup(t)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(15, gop.ThemeNone, nil)
+
+	count := 0
+	gm.Eventually(3*time.Millisecond, time.Millisecond, func(g got.G) {
+		count++
+		g.Desc("attempt").Eq(count, 0)
+	})
+	m.check("gm.Eventually(3*time.Millisecond, time.Millisecond, func(g got.G) { ...\n\n⦗should pass within⦘ 3ms ⦗but failed " + gop.Plain(count) + " attempts, the last one:⦘\n\nattempt\n" +
+		"g.Desc(\"attempt\").Eq(count, 0)\ncount = " + gop.Plain(count) + "\n" + gop.Plain(count) + " ⦗not ==⦘ 0")
+
+	gm.Eventually(0, time.Second, func(g got.G) {
+		g.Must().True(false)
+		panic("unreachable")
+	})
+	m.check("gm.Eventually(0, time.Second, func(g got.G) { ...\n ⦗should pass within⦘ 0s ⦗but failed 1 attempts, the last one:⦘  ⦗should be⦘ true")
+
+	gm.Eventually(0, time.Second, func(_ got.G) {
+		panic("err")
+	})
+	g.Has(m.msg, "[panic] err")
+	m.reset()
+
+	count = 0
+	gm.Consistently(time.Second, time.Millisecond, func(g got.G) {
+		count++
+		g.Lt(count, 3)
+	})
+	m.check(`gm.Consistently(time.Second, time.Millisecond, func(g got.G) { ...
+
+⦗should keep passing during⦘ 1s ⦗but failed at attempt 3:⦘
+
+g.Lt(count, 3)
+count = 3
+3 ⦗not <⦘ 3`)
+
+	start := time.Now()
+	canceled := false
+	gm.Eventually(10*time.Millisecond, time.Millisecond, func(g got.G) {
+		<-g.Timeout(time.Minute).Done()
+		canceled = true
+	})
+	g.True(canceled)
+	g.Lt(time.Since(start), time.Second)
+	g.Has(m.msg, "⦗but failed 1 attempts, the last one:⦘ [timeout] the attempt was still running after the deadline, its context is canceled")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Must().Consistently(time.Second, time.Millisecond, func(g got.G) {
+			g.Fail()
+		})
+	})
+	g.True(m.failed)
+}
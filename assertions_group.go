@@ -0,0 +1,83 @@
+package got
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Group runs fn and collects all the failed assertions in it, even the ones after the first failure,
+// then reports them together as one numbered report and marks the test failed only once.
+// If an assertion in fn is [Assertions.Must], the group stops at it.
+// The cleanups registered in fn run at the end of the group, the skip in fn will skip the test
+// after the failures before it are reported.
+func (as Assertions) Group(fn func(g G)) {
+	as.Helper()
+
+	r := &groupReport{handler: as.ErrorHandler}
+
+	a := as.attempt(func(g G) {
+		r.attempt = g.Testable.(*attempt)
+		g.ErrorHandler = r
+		fn(g)
+	}, time.Time{})
+
+	if a.Failed() {
+		as.err(AssertionGroup, r.count(), r.report())
+	}
+
+	// like the testing package, the test is still failed after the skip
+	if a.Skipped() {
+		as.SkipNow()
+	}
+}
+
+// groupReport wraps the ErrorHandler to record the report of each failed assertion with the logs before it,
+// such as the logs of [Assertions.Desc].
+type groupReport struct {
+	handler AssertionError
+	attempt *attempt
+
+	lock    sync.Mutex
+	entries []string
+	start   int // the index of the first log of the next entry
+}
+
+func (r *groupReport) Report(c *AssertionCtx) string {
+	report := r.handler.Report(c)
+
+	r.attempt.lock.Lock()
+	logs := r.attempt.logs[r.start:]
+	r.start = len(r.attempt.logs) + 1 // the report will be logged next, it's already in the entry
+	r.attempt.lock.Unlock()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	header := fmt.Sprintf("%d. %s:%d", len(r.entries)+1, filepath.Base(c.File), c.Line)
+	r.entries = append(r.entries, strings.Join(append(append([]string{header}, logs...), report), "\n"))
+
+	return report
+}
+
+func (r *groupReport) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.entries)
+}
+
+// report joins the entries and the logs after the last entry, such as the log of a panic
+func (r *groupReport) report() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.attempt.lock.Lock()
+	defer r.attempt.lock.Unlock()
+
+	list := append([]string{}, r.entries...)
+	if r.start < len(r.attempt.logs) {
+		list = append(list, r.attempt.logs[r.start:]...)
+	}
+	return strings.Join(list, "\n\n")
+}
@@ -0,0 +1,71 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+func TestGroup(t *testing.T) {
+	g := setup(t)
+
+	cleaned := false
+	g.Group(func(g got.G) {
+		g.Cleanup(func() { cleaned = true })
+		g.Eq(1, 1)
+	})
+	g.True(cleaned)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	gm.Group(func(g got.G) {
+		g.Eq(1, 2)
+		g.Desc("name").Eq("a", "b")
+		g.Log("after")
+		g.Must().True(false)
+		g.Eq(3, 4)
+	})
+	m.check(`
+⦗3 assertions failed in the group:⦘
+
+1. assertions_group_test.go:25
+1 ⦗not ==⦘ 2
+
+2. assertions_group_test.go:26
+name
+"a" ⦗not ==⦘ "b"
+
+3. assertions_group_test.go:28
+after
+
+ ⦗should be⦘ true`)
+
+	gm.Group(func(_ got.G) {
+		panic("err")
+	})
+	g.Has(m.msg, "⦗0 assertions failed in the group:⦘\n\n[panic] err\n")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Must().Group(func(g got.G) { g.Fail() })
+	})
+	m.reset()
+
+	gm.Group(func(g got.G) {
+		g.Eq(1, 2)
+		g.Skip()
+		g.Eq(3, 4)
+	})
+	g.True(m.failed)
+	g.Has(m.msg, "⦗1 assertions failed in the group:⦘")
+	g.Has(m.msg, "1 ⦗not ==⦘ 2")
+	m.reset()
+
+	gm.Group(func(g got.G) {
+		g.Skip()
+	})
+	g.False(m.failed)
+}
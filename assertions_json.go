@@ -0,0 +1,155 @@
+package got
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// JSONEq asserts that the json documents of actual and expected are semantically equal,
+// the key order and whitespace are ignored.
+// The actual and expected can be string, []byte, [io.Reader], or [*ResHelper].
+// The differences are reported with their JSON pointers, such as "/items/2/name".
+// There's no YAMLEq, because parsing YAML requires a third-party dependency,
+// convert the YAML documents to JSON before the assertion instead.
+func (as Assertions) JSONEq(actual, expected interface{}) {
+	as.Helper()
+
+	x, err := decodeJSON(actual)
+	if err != nil {
+		as.err(AssertionJSONInvalid, "actual", err)
+		return
+	}
+
+	y, err := decodeJSON(expected)
+	if err != nil {
+		as.err(AssertionJSONInvalid, "expected", err)
+		return
+	}
+
+	changes := diffJSON("", x, y, nil)
+	if len(changes) == 0 {
+		return
+	}
+
+	as.err(AssertionJSONEq, changes)
+}
+
+func decodeJSON(src interface{}) (v interface{}, err error) {
+	var b []byte
+	switch obj := src.(type) {
+	case []byte:
+		b = obj
+	case string:
+		b = []byte(obj)
+	case *ResHelper:
+		b = obj.Bytes().Bytes()
+	case io.Reader:
+		b, err = io.ReadAll(obj)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported json source type: %T", src)
+	}
+
+	// use json.Number to compare the numbers exactly, float64 loses the precision of the big integers, such as ids
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+// jsonChange at the JSON pointer
+type jsonChange struct {
+	pointer    string
+	actual     interface{}
+	expected   interface{}
+	missing    bool // only exists in expected
+	unexpected bool // only exists in actual
+}
+
+// diffJSON compares the decoded json values x and y, the arrays are compared item by item
+func diffJSON(pointer string, x, y interface{}, changes []jsonChange) []jsonChange {
+	switch xv := x.(type) {
+	case map[string]interface{}:
+		if yv, ok := y.(map[string]interface{}); ok {
+			keys := []string{}
+			for k := range xv {
+				keys = append(keys, k)
+			}
+			for k := range yv {
+				if _, has := xv[k]; !has {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				p := pointer + "/" + escapeJSONPointer(k)
+				xItem, xHas := xv[k]
+				yItem, yHas := yv[k]
+				switch {
+				case !xHas:
+					changes = append(changes, jsonChange{p, nil, yItem, true, false})
+				case !yHas:
+					changes = append(changes, jsonChange{p, xItem, nil, false, true})
+				default:
+					changes = diffJSON(p, xItem, yItem, changes)
+				}
+			}
+			return changes
+		}
+
+	case []interface{}:
+		if yv, ok := y.([]interface{}); ok {
+			for i := 0; i < len(xv) || i < len(yv); i++ {
+				p := fmt.Sprintf("%s/%d", pointer, i)
+				switch {
+				case i >= len(xv):
+					changes = append(changes, jsonChange{p, nil, yv[i], true, false})
+				case i >= len(yv):
+					changes = append(changes, jsonChange{p, xv[i], nil, false, true})
+				default:
+					changes = diffJSON(p, xv[i], yv[i], changes)
+				}
+			}
+			return changes
+		}
+	}
+
+	if !jsonLeafEqual(x, y) {
+		changes = append(changes, jsonChange{pointer, x, y, false, false})
+	}
+	return changes
+}
+
+// jsonLeafEqual compares the numbers by their exact values, such as 1 equals 1.0 and 1e2 equals 100
+func jsonLeafEqual(x, y interface{}) bool {
+	if xn, ok := x.(json.Number); ok {
+		if yn, ok := y.(json.Number); ok {
+			xr, _ := new(big.Rat).SetString(string(xn)) // the number is validated by the decoder, it won't fail
+			yr, _ := new(big.Rat).SetString(string(yn))
+			return xr.Cmp(yr) == 0
+		}
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapeJSONPointer(key string) string {
+	return jsonPointerEscaper.Replace(key)
+}
@@ -0,0 +1,67 @@
+package got_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+func TestJSONEq(t *testing.T) {
+	g := setup(t)
+
+	g.JSONEq(`{"a": 1, "b": [1, {"c": null}]}`, []byte(`{"b":[1,{"c":null}],"a":1.0}`))
+	g.JSONEq(bytes.NewBufferString(`[]`), `[ ]`)
+	g.JSONEq(`[100, -0, 12345678901234567890]`, `[1e2, 0.0, 12345678901234567890]`)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer s.Close()
+	g.JSONEq(g.Req("", s.URL), `{"id": 1}`)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	gm.JSONEq(`{"a": 1, "b": [1, 2], "c/~": null, "e": {"f": "x"}}`, `{"a": 2, "b": [1], "d": true, "e": {"f": "y"}}`)
+	m.check(`
+⦗json documents are not equal:⦘
+/a ⦗value⦘ 1 ⦗should be⦘ 2
+/b/1 ⦗is unexpected⦘ 2
+/c~1~0 ⦗is unexpected⦘ null
+/d ⦗is missing, it should be⦘ true
+/e/f ⦗value⦘ "x" ⦗should be⦘ "y"`)
+
+	gm.JSONEq(`[]`, `[1]`)
+	m.check(`
+⦗json documents are not equal:⦘
+/0 ⦗is missing, it should be⦘ 1`)
+
+	gm.JSONEq(`1`, `{}`)
+	m.check(`
+⦗json documents are not equal:⦘
+(root) ⦗value⦘ 1 ⦗should be⦘ {}`)
+
+	gm.JSONEq(`{"id": 12345678901234567}`, `{"id": 12345678901234568}`)
+	m.check(`
+⦗json documents are not equal:⦘
+/id ⦗value⦘ 12345678901234567 ⦗should be⦘ 12345678901234568`)
+
+	gm.JSONEq(`{`, `{}`)
+	m.check(` ⦗the actual json is invalid:⦘ unexpected EOF`)
+
+	gm.JSONEq(`{}`, `{} 1`)
+	m.check(" ⦗the expected json is invalid:⦘ unexpected data after the top-level value")
+
+	gm.JSONEq(`{}`, 1)
+	m.check(` ⦗the expected json is invalid:⦘ unsupported json source type: int`)
+
+	gm.JSONEq(iotest.ErrReader(errors.New("read err")), `{}`)
+	m.check("gm.JSONEq(iotest.ErrReader(errors.New(\"read err\")), `{}`)\n ⦗the actual json is invalid:⦘ read err")
+}
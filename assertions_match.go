@@ -0,0 +1,217 @@
+package got
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ysmood/got/lib/utils"
+)
+
+// Match asserts that x matches the pattern partially.
+// If the pattern is a map or struct, only the keys or the non-zero exported fields of it will be compared,
+// the key of the map pattern can match either the key of a map or the field name of a struct.
+// If the pattern is a slice or array, x should have the same length and each item should match.
+// If the pattern is a [Matcher], such as [AnyString], [MatchRegex], or [InRange], it will be used to match x.
+// Others will be compared via [utils.SmartCompare]. Such as:
+//
+//	Match(user, map[string]any{"Name": "bob", "Items": []any{Any, map[string]any{"ID": AnyNumber}}})
+func (as Assertions) Match(x, pattern interface{}) {
+	as.Helper()
+
+	m := matchPattern("$", x, pattern)
+	if m == nil {
+		return
+	}
+
+	as.err(AssertionMatch, m.path, m.x, m.pattern, m.missing)
+}
+
+type mismatch struct {
+	path       string
+	x, pattern interface{}
+	missing    bool
+}
+
+func matchPattern(path string, x, pattern interface{}) *mismatch {
+	if m, ok := pattern.(Matcher); ok {
+		if m.Match(x) {
+			return nil
+		}
+		return &mismatch{path, x, m.String(), false}
+	}
+
+	if utils.SmartCompare(x, pattern) == 0 {
+		return nil
+	}
+
+	fail := &mismatch{path, x, pattern, false}
+
+	xv := reflect.Indirect(reflect.ValueOf(x))
+	pv := reflect.Indirect(reflect.ValueOf(pattern))
+
+	switch pv.Kind() {
+	case reflect.Map, reflect.Struct:
+		if xv.Kind() != reflect.Map && xv.Kind() != reflect.Struct {
+			return fail
+		}
+
+		keys, vals := patternFields(pv)
+		for i, k := range keys {
+			p := path + pathKey(k)
+			item, has := lookupKey(xv, k)
+			if !has {
+				return &mismatch{p, nil, vals[i], true}
+			}
+			if m := matchPattern(p, item, vals[i]); m != nil {
+				return m
+			}
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if (xv.Kind() != reflect.Slice && xv.Kind() != reflect.Array) || xv.Len() != pv.Len() {
+			return fail
+		}
+
+		for i := 0; i < pv.Len(); i++ {
+			if m := matchPattern(fmt.Sprintf("%s[%d]", path, i), xv.Index(i).Interface(), pv.Index(i).Interface()); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+
+	return fail
+}
+
+// patternFields returns the sorted keys of a map, or the names of the non-zero exported fields of a struct
+func patternFields(pv reflect.Value) ([]interface{}, []interface{}) {
+	keys := []interface{}{}
+	vals := []interface{}{}
+
+	if pv.Kind() == reflect.Struct {
+		for i := 0; i < pv.NumField(); i++ {
+			if pv.Type().Field(i).IsExported() && !pv.Field(i).IsZero() {
+				keys = append(keys, pv.Type().Field(i).Name)
+				vals = append(vals, pv.Field(i).Interface())
+			}
+		}
+		return keys, vals
+	}
+
+	mapKeys := pv.MapKeys()
+	sort.Slice(mapKeys, func(i, j int) bool {
+		return fmt.Sprint(mapKeys[i].Interface()) < fmt.Sprint(mapKeys[j].Interface())
+	})
+	for _, k := range mapKeys {
+		keys = append(keys, k.Interface())
+		vals = append(vals, pv.MapIndex(k).Interface())
+	}
+	return keys, vals
+}
+
+func lookupKey(xv reflect.Value, k interface{}) (interface{}, bool) {
+	if xv.Kind() == reflect.Struct {
+		name, ok := k.(string)
+		if !ok {
+			return nil, false
+		}
+		f := xv.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+		return f.Interface(), true
+	}
+
+	for _, xk := range xv.MapKeys() {
+		if utils.SmartCompare(xk.Interface(), k) == 0 {
+			return xv.MapIndex(xk).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+var regIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func pathKey(k interface{}) string {
+	if s, ok := k.(string); ok {
+		if regIdentifier.MatchString(s) {
+			return "." + s
+		}
+		return "[" + strconv.Quote(s) + "]"
+	}
+	return fmt.Sprintf("[%v]", k)
+}
+
+// ElementsMatch asserts that the slices or arrays x and y have the same elements regardless of the order,
+// the duplicated elements should have the same count.
+// For how comparison works, see [utils.SmartCompare] .
+func (as Assertions) ElementsMatch(x, y interface{}) {
+	as.Helper()
+
+	for i, list := range []interface{}{x, y} {
+		if k := reflect.ValueOf(list).Kind(); k != reflect.Slice && k != reflect.Array {
+			as.err(AssertionNotList, []string{"former", "latter"}[i], list)
+			return
+		}
+	}
+
+	xs, ys := elementGroups(x), elementGroups(y)
+
+	onlyX, onlyY := []interface{}{}, []interface{}{}
+	counts := []elementCount{}
+
+	for _, xg := range xs {
+		yg := findElementGroup(ys, xg.val)
+		switch {
+		case yg == nil:
+			onlyX = append(onlyX, xg.val)
+		case yg.x != xg.x:
+			counts = append(counts, elementCount{xg.val, xg.x, yg.x})
+		}
+	}
+	for _, yg := range ys {
+		if findElementGroup(xs, yg.val) == nil {
+			onlyY = append(onlyY, yg.val)
+		}
+	}
+
+	if len(onlyX) == 0 && len(onlyY) == 0 && len(counts) == 0 {
+		return
+	}
+
+	as.err(AssertionElementsMatch, x, y, onlyX, onlyY, counts)
+}
+
+// elementCount is the count of the same elements in x, and y if it's compared with the other list
+type elementCount struct {
+	val  interface{}
+	x, y int
+}
+
+// elementGroups groups the same elements of the list in the order of their first appearance
+func elementGroups(list interface{}) []*elementCount {
+	v := reflect.ValueOf(list)
+	groups := []*elementCount{}
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i).Interface()
+		if g := findElementGroup(groups, item); g != nil {
+			g.x++
+		} else {
+			groups = append(groups, &elementCount{val: item, x: 1})
+		}
+	}
+	return groups
+}
+
+func findElementGroup(groups []*elementCount, val interface{}) *elementCount {
+	for _, g := range groups {
+		if utils.SmartCompare(g.val, val) == 0 {
+			return g
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+type matchUser struct {
+	Name  string
+	Age   int
+	Items []matchItem
+	tag   string
+}
+
+type matchItem struct {
+	ID   int
+	Name string
+}
+
+func TestMatch(t *testing.T) {
+	g := setup(t)
+
+	u := &matchUser{"bob", 20, []matchItem{{1, "a"}, {2, "b"}}, "x"}
+
+	g.Match(u, u)
+	g.Match(u, matchUser{Name: "bob"})
+	g.Match(u, map[string]interface{}{
+		"Name":  got.AnyString,
+		"Age":   got.InRange(18, 30),
+		"Items": []interface{}{got.Any, map[string]interface{}{"ID": 2, "Name": got.MatchRegex(`^b$`)}},
+	})
+	g.Match(map[int]string{1: "a", 2: "b"}, map[int]string{2: "b"})
+	g.Match([2]int{1, 2}, []int{1, 2})
+}
+
+func TestMatchErr(t *testing.T) {
+	m := &mock{t: t}
+	g := got.New(m)
+	g.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	u := matchUser{"bob", 20, []matchItem{{1, "a"}, {2, "b"}}, "x"}
+
+	g.Match(u, map[string]interface{}{"Items": []interface{}{got.Any, matchItem{Name: "c"}}})
+	m.check(`g.Match(u, map[string]interface{}{"Items": []interface{}{got.Any, matchItem{Name: "c"}}})
+$.Items[1].Name ⦗value⦘ "b" ⦗should match⦘ "c"`)
+
+	g.Match(u, map[string]interface{}{"Age": got.InRange(1, 10)})
+	m.check(`g.Match(u, map[string]interface{}{"Age": got.InRange(1, 10)})
+$.Age ⦗value⦘ 20 ⦗should match⦘ "<range 1..10>"`)
+
+	g.Match(map[string][]int{"a": {1, 2}}, map[string][]int{"a": {1}})
+	m.check(`
+$.a
+
+⦗value⦘
+
+[]int{
+    1,
+    2,
+}
+
+⦗should match⦘
+
+[]int{
+    1,
+}`)
+
+	g.Match("a", []int{})
+	m.check(`$ ⦗value⦘ "a" ⦗should match⦘ []int{}`)
+
+	g.Match(u, map[string]interface{}{"tag": "x"})
+	m.check(`g.Match(u, map[string]interface{}{"tag": "x"})
+$.tag ⦗is missing, it should match⦘ "x"`)
+
+	g.Match(u, map[interface{}]int{1: 1})
+	m.check(`g.Match(u, map[interface{}]int{1: 1})
+$[1] ⦗is missing, it should match⦘ 1`)
+
+	g.Match(map[string]int{}, map[string]int{"a b": 1})
+	m.check(`$["a b"] ⦗is missing, it should match⦘ 1`)
+
+	g.Match(1, map[string]int{})
+	m.check(`$ ⦗value⦘ 1 ⦗should match⦘ map[string]int{}`)
+
+	g.Match(1, 2)
+	m.check(`$ ⦗value⦘ 1 ⦗should match⦘ 2`)
+}
+
+func TestElementsMatch(t *testing.T) {
+	g := setup(t)
+
+	g.ElementsMatch([]int{1, 2, 2, 3}, [4]interface{}{2, 3.0, 1, 2})
+	g.ElementsMatch([]string{}, []int{})
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	gm.ElementsMatch([]int{1, 1, 2}, []int{2, 1})
+	m.check(`
+[]int{
+    1,
+    1,
+    2,
+}
+
+⦗should match the elements of⦘
+
+[]int{
+    2,
+    1,
+}
+
+⦗the count of⦘ 1 ⦗is⦘ 2 ⦗but should be⦘ 1`)
+
+	gm.ElementsMatch([]int{1, 3}, []int{2, 1})
+	m.check(`
+[]int{
+    1,
+    3,
+}
+
+⦗should match the elements of⦘
+
+[]int{
+    2,
+    1,
+}
+
+⦗only in the former⦘
+
+[]interface {}{
+    3,
+}
+
+⦗only in the latter⦘
+
+[]interface {}{
+    2,
+}`)
+
+	gm.ElementsMatch(nil, []int{1})
+	m.check(` ⦗the former should be a slice or an array, but got⦘ nil`)
+
+	gm.ElementsMatch([]int{1}, map[int]int{1: 1})
+	m.check(`
+⦗the latter should be a slice or an array, but got⦘
+
+map[int]int{
+    1: 1,
+}`)
+
+	xs, ys := []int{1}, "abc"
+	gm.ElementsMatch(xs, ys)
+	m.check(`gm.ElementsMatch(xs, ys)
+ ⦗the latter should be a slice or an array, but got⦘ "abc"`)
+}
@@ -0,0 +1,71 @@
+package got
+
+import (
+	"fmt"
+	"regexp"
+	"runtime/debug"
+
+	"github.com/ysmood/got/lib/utils"
+)
+
+// PanicWith executes fn and asserts that fn panics with the value equals expected.
+// For how comparison works, see [utils.SmartCompare] .
+func (as Assertions) PanicWith(fn func(), expected interface{}) {
+	as.Helper()
+
+	val, stack, panicked := capturePanic(fn)
+	if !panicked {
+		as.err(AssertionPanic, fn)
+		return
+	}
+
+	if utils.SmartCompare(val, expected) == 0 {
+		return
+	}
+	as.err(AssertionPanicWith, val, expected, stack)
+}
+
+// PanicMatch executes fn and asserts that fn panics with the value matches the regex pattern.
+// If the value is an error, its message will be matched, others will be formatted via [fmt.Sprint].
+func (as Assertions) PanicMatch(fn func(), pattern string) {
+	as.Helper()
+
+	val, stack, panicked := capturePanic(fn)
+	if !panicked {
+		as.err(AssertionPanic, fn)
+		return
+	}
+
+	if regexp.MustCompile(pattern).MatchString(fmt.Sprint(val)) {
+		return
+	}
+	as.err(AssertionPanicMatch, val, pattern, stack)
+}
+
+// NotPanic executes fn and asserts that fn doesn't panic.
+// The panic will be recovered and reported with the stack of the panic point.
+func (as Assertions) NotPanic(fn func()) {
+	as.Helper()
+
+	val, stack, panicked := capturePanic(fn)
+	if !panicked {
+		return
+	}
+	as.err(AssertionNotPanic, val, stack)
+}
+
+// capturePanic executes fn and returns the recovered value and the goroutine stack at the panic point
+func capturePanic(fn func()) (val interface{}, stack string, panicked bool) {
+	defer func() {
+		val = recover()
+		if panicked {
+			stack = string(debug.Stack())
+		}
+	}()
+
+	panicked = true
+	fn()
+	panicked = false
+
+	return
+}
@@ -0,0 +1,45 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+func TestPanicAssertions(t *testing.T) {
+	g := setup(t)
+
+	g.PanicWith(func() { panic(1) }, 1.0)
+	g.PanicMatch(func() { panic(errors.New("timeout after 3s")) }, `^timeout after \d+s$`)
+	g.NotPanic(func() {})
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	gm.PanicWith(func() {}, 1)
+	m.check(` ⦗should panic⦘ `)
+
+	gm.PanicMatch(func() {}, `x`)
+	m.check(` ⦗should panic⦘ `)
+
+	gm.PanicWith(func() { panic("a") }, "b")
+	g.Has(m.msg, "⦗panic value⦘\n\n\"a\"\n\n⦗should be⦘\n\n\"b\"\n\ngoroutine ")
+	g.Has(m.msg, "got_test.TestPanicAssertions.func")
+	m.reset()
+
+	gm.PanicMatch(func() { panic("a") }, `b`)
+	g.Has(m.msg, "⦗panic value⦘\n\n\"a\"\n\n⦗should match⦘\n\n\"b\"\n\ngoroutine ")
+	m.reset()
+
+	gm.NotPanic(func() { panic("a") })
+	g.Has(m.msg, "⦗should not panic, but panicked with⦘\n\n\"a\"\n\ngoroutine ")
+	g.Has(m.msg, "assertions_panic_test.go")
+	m.reset()
+
+	gm.NotPanic(func() { panic(nil) })
+	g.Has(m.msg, "runtime.PanicNilError")
+	m.reset()
+}
@@ -0,0 +1,118 @@
+package got
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// the prefix of the functions in this package, such as "github.com/ysmood/got.Assertions.Eq"
+var pkgPrefix = reflect.TypeOf(Assertions{}).PkgPath() + "."
+
+// callSite returns the file and line of the first caller outside this package,
+// and the name of the function of this package it calls, such as "Eq".
+func callSite() (file string, line int, name string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, pkgPrefix) {
+			return frame.File, frame.Line, name
+		}
+		name = frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+	}
+}
+
+type sourceFile struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+}
+
+// sourceFiles caches the parsed source files, the value is nil if the file can't be parsed
+var sourceFiles = sync.Map{}
+
+func loadSourceFile(path string) *sourceFile {
+	if f, has := sourceFiles.Load(path); has {
+		return f.(*sourceFile)
+	}
+
+	var sf *sourceFile
+	if src, err := os.ReadFile(path); err == nil {
+		fset := token.NewFileSet()
+		if f, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution); err == nil {
+			sf = &sourceFile{fset, f, src}
+		}
+	}
+
+	sourceFiles.Store(path, sf)
+	return sf
+}
+
+// callSource returns the source code of the call of the method at the line and the source of its arguments,
+// it returns empty if the call can't be found.
+func callSource(path string, line int, method string) (source string, args []string) {
+	sf := loadSourceFile(path)
+	if sf == nil {
+		return
+	}
+
+	text := func(n ast.Node) string {
+		return string(sf.src[sf.fset.Position(n.Pos()).Offset:sf.fset.Position(n.End()).Offset])
+	}
+
+	ast.Inspect(sf.file, func(n ast.Node) bool {
+		if source != "" {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok || sf.fset.Position(call.Pos()).Line > line || sf.fset.Position(call.End()).Line < line {
+			return true
+		}
+
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == method {
+			source = text(call)
+			if i := strings.Index(source, "\n"); i > 0 {
+				source = source[:i] + " ..."
+			}
+			for _, arg := range call.Args {
+				args = append(args, text(arg))
+			}
+			return false
+		}
+
+		return true
+	})
+
+	return
+}
+
+// isLiteral returns true if the expression is a literal value, such as `1`, `"a"`, `nil`, or `[]int{1, 2}`.
+// The function literal is also treated as literal, because its value is meaningless in the report.
+func isLiteral(e ast.Expr) bool {
+	switch v := e.(type) {
+	case *ast.BasicLit, *ast.FuncLit:
+		return true
+	case *ast.Ident:
+		return v.Name == "true" || v.Name == "false" || v.Name == "nil"
+	case *ast.UnaryExpr:
+		return isLiteral(v.X)
+	case *ast.KeyValueExpr:
+		return isLiteral(v.Value)
+	case *ast.CompositeLit:
+		for _, elt := range v.Elts {
+			if !isLiteral(elt) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ysmood/gop"
 	"github.com/ysmood/got"
+	"github.com/ysmood/got/lib/diff"
 )
 
 func TestAssertion(t *testing.T) {
@@ -144,6 +145,13 @@ got_test.data{
     2,
 }`)
 
+	// the strict comparison respects the location of time
+	t0 := time.Unix(0, 0).UTC()
+	as.Equal(t0, t0.In(time.FixedZone("X", 3600)))
+	m.check(`as.Equal(t0, t0.In(time.FixedZone("X", 3600)))
+t0 = gop.Time("1970-01-01T00:00:00Z", 62135596800)
+gop.Time("1970-01-01T00:00:00Z", 62135596800) ⦗not ==⦘ gop.Time("1970-01-01T01:00:00+01:00", 62135596800)`)
+
 	as.Neq(1, 1)
 	m.check("1 ⦗==⦘ 1")
 	as.Neq(1.0, 1)
@@ -174,7 +182,7 @@ got_test.data{
 	as.NotNil(nil)
 	m.check(" ⦗last argument shouldn't be⦘ nil")
 	as.NotNil((*int)(nil))
-	m.check(" ⦗last argument⦘ (*int)(nil) ⦗shouldn't be⦘ nil")
+	m.check("as.NotNil((*int)(nil))\n ⦗last argument⦘ (*int)(nil) ⦗shouldn't be⦘ nil")
 	as.NotNil()
 	m.check(" ⦗no arguments received⦘ ")
 	as.NotNil(1)
@@ -208,7 +216,8 @@ got_test.data{
 		}()
 		as.E(1, errors.New("E"))
 	}()
-	m.check(`
+	m.check(`as.E(1, errors.New("E"))
+
 ⦗last argument⦘
 
 &errors.errorString{
@@ -222,7 +231,8 @@ nil`)
 	as.Is(1, 2.2)
 	m.check("1 ⦗should be kind of⦘ 2.2")
 	as.Is(errors.New("a"), errors.New("b"))
-	m.check(`
+	m.check(`as.Is(errors.New("a"), errors.New("b"))
+
 &errors.errorString{
     s: "a",
 }
@@ -233,7 +243,8 @@ nil`)
     s: "b",
 }`)
 	as.Is(nil, errors.New("a"))
-	m.check(`
+	m.check(`as.Is(nil, errors.New("a"))
+
 nil
 
 ⦗should be kind of⦘
@@ -242,7 +253,8 @@ nil
     s: "a",
 }`)
 	as.Is(errors.New("a"), nil)
-	m.check(`
+	m.check(`as.Is(errors.New("a"), nil)
+
 &errors.errorString{
     s: "a",
 }
@@ -292,11 +304,37 @@ func TestAssertionColor(t *testing.T) {
     <32>3<39>,
 }
 
-<45><30>@@ diff chunk @@<39><49>
-2 2       1,
-<31>3   -<39>     <31>2<39>,
-<32>  3 +<39>     <32>3<39>,
-4 4   }
+~ [1]: <31>2<39> → <32>3<39>`)
+
+	type data struct{ A, B int }
+	g.Eq(&data{1, 2}, &data{1, 3})
+	m.checkWithStyle(true, `
+&<36>got_test.data<39>{
+    A: <32>1<39>,
+    B: <32>2<39>,
+}
+
+<31><4>⦗not ==⦘<24><39>
+
+&<36>got_test.data<39>{
+    A: <32>1<39>,
+    B: <32>3<39>,
+}
+
+~ .B: <31>2<39> → <32>3<39>`)
+
+	// the composite values without structural changes fall back to the text diff
+	eh := got.NewDefaultAssertionError(0, gop.ThemeNone, diff.ThemeNone)
+	got.T(t).Eq(eh.Report(&got.AssertionCtx{Type: got.AssertionEq, Details: []interface{}{[]int{1}, []int{1}}}), `
+[]int{
+    1,
+}
+
+⦗not ==⦘
+
+[]int{
+    1,
+}
 
 `)
 
@@ -307,6 +345,33 @@ func TestAssertionColor(t *testing.T) {
 	m.checkWithStyle(true, `<31>3<39> <31><4>⦗not ==⦘<24><39> <32>"a"<39>`)
 }
 
+func TestAssertionSource(t *testing.T) {
+	m := &mock{t: t}
+
+	g := got.New(m)
+	g.ErrorHandler = got.NewDefaultAssertionError(0, gop.ThemeNone, nil)
+
+	user := struct{ Name string }{"alice"}
+	g.Eq(user.Name, "bob")
+	m.check(`g.Eq(user.Name, "bob")
+user.Name = "alice"
+"alice" ⦗not ==⦘ "bob"`)
+
+	g.Eq(user, "bob")
+	m.check(`g.Eq(user, "bob")
+
+struct { Name string }{
+    Name: "alice",
+}
+
+⦗not ==⦘
+
+"bob"`)
+
+	g.Eq("alice", "bob")
+	m.check(`"alice" ⦗not ==⦘ "bob"`)
+}
+
 func TestCustomAssertionError(t *testing.T) {
 	m := &mock{t: t}
 
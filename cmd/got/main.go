@@ -0,0 +1,263 @@
+// Package main is the command line tool of got.
+//
+// Usage:
+//
+//	got snapshot [flags] list|prune|review
+//
+// The "list" lists the snapshots of each test and the orphaned snapshot dirs of the tests that no longer exist.
+// The "prune" lists the orphaned snapshot dirs and asks to remove them, use the "-yes" flag to skip the question.
+// The "review" interactively accepts or rejects the pending snapshot updates saved as ".new" files.
+// The "list" and "prune" fail if no test function is found in the package dir, to avoid treating all the snapshots as orphans.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ysmood/got"
+	"github.com/ysmood/got/lib/diff"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		exit(1)
+	}
+}
+
+var exit = os.Exit
+
+func run(args []string, in io.Reader, out io.Writer) error {
+	if len(args) < 1 || args[0] != "snapshot" {
+		return errors.New("usage: got snapshot [flags] list|prune|review")
+	}
+
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.SetOutput(out)
+	dir := fs.String("dir", ".", "the package dir of the test files")
+	root := fs.String("root", filepath.Join(".got", "snapshots"), "the root dir of the snapshots, relative to the package dir")
+	yes := fs.Bool("yes", false, "remove the orphaned snapshot dirs without asking when pruning")
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	s := &snapshots{filepath.Join(*dir, *root), *dir, in, out, os.RemoveAll}
+
+	switch fs.Arg(0) {
+	case "list", "":
+		return s.list()
+	case "prune":
+		return s.prune(*yes)
+	case "review":
+		return s.review()
+	default:
+		return fmt.Errorf("unknown action: %s", fs.Arg(0))
+	}
+}
+
+type snapshots struct {
+	root string
+	pkg  string
+	in   io.Reader
+	out  io.Writer
+
+	removeAll func(path string) error
+}
+
+// walk the snapshot files, it does nothing if the root doesn't exist
+func (s *snapshots) walk(fn func(path string) error) error {
+	return filepath.WalkDir(s.root, func(path string, d os.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == s.root {
+			return nil
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return fn(path)
+	})
+}
+
+// list prints the snapshot files grouped by the test name
+func (s *snapshots) list() error {
+	groups := map[string][]string{}
+	err := s.walk(func(path string) error {
+		rel, _ := filepath.Rel(s.root, path)
+		name := filepath.ToSlash(filepath.Dir(rel))
+		file := filepath.Base(path)
+		if strings.HasSuffix(file, got.SnapshotNewExt) {
+			file += " (pending)"
+		}
+		groups[name] = append(groups[name], file)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintln(s.out, name)
+		for _, file := range groups[name] {
+			fmt.Fprintln(s.out, "  "+file)
+		}
+	}
+
+	orphans, err := s.orphans()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range orphans {
+		fmt.Fprintln(s.out, "orphaned: "+name)
+	}
+
+	return nil
+}
+
+// prune removes the orphaned snapshot dirs, it asks for confirmation first unless yes is true
+func (s *snapshots) prune(yes bool) error {
+	orphans, err := s.orphans()
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	for _, name := range orphans {
+		fmt.Fprintln(s.out, "orphaned: "+name)
+	}
+
+	if !yes {
+		fmt.Fprint(s.out, "remove the orphaned snapshot dirs? [y]es, [n]o: ")
+
+		in := bufio.NewScanner(s.in)
+		if !in.Scan() {
+			fmt.Fprintln(s.out, "\nnothing is removed")
+			return in.Err()
+		}
+
+		switch strings.TrimSpace(in.Text()) {
+		case "y", "yes":
+		default:
+			fmt.Fprintln(s.out, "nothing is removed")
+			return nil
+		}
+	}
+
+	for _, name := range orphans {
+		err := s.removeAll(filepath.Join(s.root, name))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(s.out, "removed: "+name)
+	}
+
+	return nil
+}
+
+// review asks to accept or reject each pending snapshot update
+func (s *snapshots) review() error {
+	pending := []string{}
+	err := s.walk(func(path string) error {
+		if strings.HasSuffix(path, got.SnapshotNewExt) {
+			pending = append(pending, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	in := bufio.NewScanner(s.in)
+
+	for _, path := range pending {
+		old := strings.TrimSuffix(path, got.SnapshotNewExt)
+
+		oldData, _ := os.ReadFile(old)
+		newData, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(s.root, old)
+		fmt.Fprintf(s.out, "%s\n\n%s\n", filepath.ToSlash(rel), diff.Diff(string(oldData), string(newData)))
+		fmt.Fprint(s.out, "accept the update? [y]es, [n]o, [s]kip: ")
+
+		if !in.Scan() {
+			return in.Err()
+		}
+
+		switch strings.TrimSpace(in.Text()) {
+		case "y", "yes":
+			err = os.Rename(path, old)
+		case "n", "no":
+			err = os.Remove(path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var regTestFunc = regexp.MustCompile(`^(Test|Benchmark|Fuzz)`)
+
+// orphans returns the top-level snapshot dirs that have no corresponding test function in the package.
+// The subtests can't be detected statically, so only the top-level tests are checked.
+func (s *snapshots) orphans() ([]string, error) {
+	tests := map[string]bool{}
+
+	paths, _ := filepath.Glob(filepath.Join(s.pkg, "*_test.go"))
+	for _, path := range paths {
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && regTestFunc.MatchString(fn.Name.Name) {
+				tests[fn.Name.Name] = true
+			}
+		}
+	}
+
+	// without any test, all the snapshot dirs would be orphans, such as when the -dir is wrong
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("no test function is found in the dir: %s", s.pkg)
+	}
+
+	entries, err := os.ReadDir(s.root)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	list := []string{}
+	for _, e := range entries {
+		if e.IsDir() && !tests[e.Name()] {
+			list = append(list, e.Name())
+		}
+	}
+
+	return list, nil
+}
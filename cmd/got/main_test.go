@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+func setup(t *testing.T) (got.G, *snapshots, *bytes.Buffer) {
+	g := got.T(t)
+
+	pkg := t.TempDir()
+	root := filepath.Join(pkg, ".got", "snapshots")
+
+	g.WriteFile(filepath.Join(pkg, "a_test.go"), "package a\n\nfunc TestA(t *testing.T) {}\n\nfunc (c C) TestB() {}\n")
+	g.WriteFile(filepath.Join(root, "TestA", "a.json"), "1")
+	g.WriteFile(filepath.Join(root, "TestA", "a.json.new"), "2")
+	g.WriteFile(filepath.Join(root, "TestA", "b.json.new"), "3")
+	g.WriteFile(filepath.Join(root, "TestA", "sub", "c.json"), "3")
+	g.WriteFile(filepath.Join(root, "TestB", "d.json"), "4")
+
+	out := bytes.NewBuffer(nil)
+	return g, &snapshots{root, pkg, strings.NewReader(""), out, os.RemoveAll}, out
+}
+
+func TestList(t *testing.T) {
+	g, s, out := setup(t)
+
+	g.E(s.list())
+	g.Eq(out.String(), "TestA\n  a.json\n  a.json.new (pending)\n  b.json.new (pending)\n"+
+		"TestA/sub\n  c.json\nTestB\n  d.json\norphaned: TestB\n")
+
+	s.root = filepath.Join(s.pkg, "not-exists")
+	out.Reset()
+	g.E(s.list())
+	g.Eq(out.String(), "")
+
+	s.root = "\x00" // an invalid path
+	g.Err(s.list())
+
+	g.WriteFile(filepath.Join(s.pkg, "b_test.go"), "package")
+	g.Err(s.list())
+}
+
+func TestPrune(t *testing.T) {
+	g, s, out := setup(t)
+
+	g.E(s.prune(false))
+	g.Eq(out.String(), "orphaned: TestB\nremove the orphaned snapshot dirs? [y]es, [n]o: \nnothing is removed\n")
+	g.True(g.PathExists(filepath.Join(s.root, "TestB")))
+
+	out.Reset()
+	s.in = strings.NewReader("n\n")
+	g.E(s.prune(false))
+	g.Eq(out.String(), "orphaned: TestB\nremove the orphaned snapshot dirs? [y]es, [n]o: nothing is removed\n")
+	g.True(g.PathExists(filepath.Join(s.root, "TestB")))
+
+	out.Reset()
+	s.in = strings.NewReader("y\n")
+	g.E(s.prune(false))
+	g.Eq(out.String(), "orphaned: TestB\nremove the orphaned snapshot dirs? [y]es, [n]o: removed: TestB\n")
+	g.False(g.PathExists(filepath.Join(s.root, "TestB")))
+	g.True(g.PathExists(filepath.Join(s.root, "TestA")))
+
+	out.Reset()
+	g.E(s.prune(false))
+	g.Eq(out.String(), "")
+
+	g.WriteFile(filepath.Join(s.root, "TestC", "e.json"), "5")
+	g.E(s.prune(true))
+	g.Eq(out.String(), "orphaned: TestC\nremoved: TestC\n")
+	g.False(g.PathExists(filepath.Join(s.root, "TestC")))
+
+	g.WriteFile(filepath.Join(s.root, "TestD", "f.json"), "6")
+	s.removeAll = func(string) error { return errors.New("err") }
+	g.Err(s.prune(true))
+
+	s.root = "\x00"
+	g.Err(s.prune(true))
+
+	g.WriteFile(filepath.Join(s.pkg, "b_test.go"), "package")
+	g.Err(s.prune(true))
+}
+
+func TestNoTests(t *testing.T) {
+	g, s, _ := setup(t)
+
+	s.pkg = t.TempDir()
+	g.Eq(s.prune(true).Error(), "no test function is found in the dir: "+s.pkg)
+	g.True(g.PathExists(filepath.Join(s.root, "TestA")))
+}
+
+func TestReview(t *testing.T) {
+	g, s, out := setup(t)
+
+	s.in = strings.NewReader("y\nn\n")
+	g.E(s.review())
+	g.Has(gop.StripANSI(out.String()), "TestA/a.json\n\n@@ diff chunk @@\n1   - 1\n  1 + 2\n\n\naccept the update?")
+	g.Eq(g.Read(filepath.Join(s.root, "TestA", "a.json")).String(), "2")
+	g.False(g.PathExists(filepath.Join(s.root, "TestA", "a.json.new")))
+	g.False(g.PathExists(filepath.Join(s.root, "TestA", "b.json.new")))
+
+	g.WriteFile(filepath.Join(s.root, "TestA", "a.json.new"), "3")
+	s.in = strings.NewReader("s\n")
+	g.E(s.review())
+	g.True(g.PathExists(filepath.Join(s.root, "TestA", "a.json.new")))
+
+	s.in = strings.NewReader("")
+	g.E(s.review())
+
+	// the old snapshot is a dir, the update can't replace it
+	g.WriteFile(filepath.Join(s.root, "TestA", "sub.new"), "4")
+	s.in = strings.NewReader("s\ny\n")
+	g.Err(s.review())
+	g.E(os.Remove(filepath.Join(s.root, "TestA", "sub.new")))
+
+	g.E(os.Symlink(filepath.Join(s.pkg, "not-exists"), filepath.Join(s.root, "TestA", "e.json.new")))
+	s.in = strings.NewReader("s\n")
+	g.Err(s.review())
+
+	s.root = "\x00"
+	g.Err(s.review())
+}
+
+func TestRun(t *testing.T) {
+	g, s, out := setup(t)
+
+	g.Eq(run(nil, nil, out).Error(), "usage: got snapshot [flags] list|prune|review")
+	g.Err(run([]string{"snapshot", "-unknown"}, nil, out))
+	g.Eq(run([]string{"snapshot", "-dir", s.pkg, "unknown"}, nil, out).Error(), "unknown action: unknown")
+
+	out.Reset()
+	g.E(run([]string{"snapshot", "-dir", s.pkg}, nil, out))
+	g.Has(out.String(), "orphaned: TestB\n")
+
+	out.Reset()
+	g.E(run([]string{"snapshot", "-dir", s.pkg, "list"}, nil, out))
+	g.Has(out.String(), "TestA/sub\n")
+
+	out.Reset()
+	g.E(run([]string{"snapshot", "-dir", s.pkg, "review"}, strings.NewReader("s\ns\n"), out))
+	g.Has(out.String(), "accept the update?")
+
+	out.Reset()
+	g.E(run([]string{"snapshot", "-dir", s.pkg, "-yes", "prune"}, nil, out))
+	g.Eq(out.String(), "orphaned: TestB\nremoved: TestB\n")
+}
+
+func TestMainExit(t *testing.T) {
+	g := got.T(t)
+
+	args := os.Args
+	defer func() {
+		os.Args = args
+		exit = os.Exit
+	}()
+
+	code := -1
+	exit = func(c int) { code = c }
+
+	os.Args = []string{"got", "snapshot", "-dir", t.TempDir(), "list"}
+	main()
+	g.Eq(code, 1)
+
+	code = -1
+	os.Args = []string{"got", "snapshot", "-dir", ".", "list"}
+	main()
+	g.Eq(code, -1)
+}
@@ -0,0 +1,247 @@
+package got
+
+import (
+	"go/token"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got/lib/structdiff"
+	"github.com/ysmood/got/lib/utils"
+)
+
+// CompareOption relaxes the comparison of [Assertions.Eq] and [Assertions.Equal], such as:
+//
+//	Eq(x, y, IgnoreFields("UpdatedAt"), FloatTolerance(1e-9), IgnoreUnexported(), EquateEmpty())
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	fields           []string
+	floatTolerance   float64
+	ignoreUnexported bool
+	equateEmpty      bool
+}
+
+// IgnoreFields ignores the struct fields with the names at any depth,
+// the name can also be the dot-separated field path from the root value, such as "User.UpdatedAt".
+func IgnoreFields(names ...string) CompareOption {
+	return func(o *compareOptions) {
+		o.fields = append(o.fields, names...)
+	}
+}
+
+// FloatTolerance treats the numbers as equal if either of them is a float and
+// the absolute difference between them is not greater than tolerance.
+func FloatTolerance(tolerance float64) CompareOption {
+	return func(o *compareOptions) {
+		o.floatTolerance = tolerance
+	}
+}
+
+// IgnoreUnexported ignores the unexported struct fields
+func IgnoreUnexported() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreUnexported = true
+	}
+}
+
+// EquateEmpty treats the nil and empty slices or maps as equal
+func EquateEmpty() CompareOption {
+	return func(o *compareOptions) {
+		o.equateEmpty = true
+	}
+}
+
+type visitPair struct {
+	x, y uintptr
+	t    reflect.Type
+}
+
+type comparison struct {
+	compareOptions
+	strict  bool
+	visited map[visitPair]bool
+}
+
+// compareWithOptions returns true if x equals y under the options,
+// the values are compared via [utils.Compare] if strict is true, or via [utils.SmartCompare].
+func compareWithOptions(x, y interface{}, strict bool, opts []CompareOption) bool {
+	return newComparison(strict, opts).equal("", reflect.ValueOf(x), reflect.ValueOf(y))
+}
+
+func newComparison(strict bool, opts []CompareOption) *comparison {
+	c := &comparison{strict: strict, visited: map[visitPair]bool{}}
+	for _, opt := range opts {
+		opt(&c.compareOptions)
+	}
+	return c
+}
+
+func (c *comparison) equal(path string, x, y reflect.Value) bool {
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid() || c.empty(x) && c.empty(y)
+	}
+
+	if c.empty(x) && c.empty(y) {
+		return true
+	}
+
+	cmp := utils.SmartCompare
+	if c.strict {
+		cmp = utils.Compare
+	}
+
+	if cmp(x.Interface(), y.Interface()) == 0 {
+		return true
+	}
+
+	if x.Type() != y.Type() && c.strict {
+		return false
+	}
+
+	if c.floatTolerance > 0 && isNumber(x) && isNumber(y) && (isFloat(x) || isFloat(y)) {
+		return math.Abs(utils.SmartCompare(x.Interface(), y.Interface())) <= c.floatTolerance
+	}
+
+	if x.Type() != y.Type() || utils.HasCompareMethod(x.Type()) {
+		return false
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+
+		v := visitPair{x.Pointer(), y.Pointer(), x.Type()}
+		if c.visited[v] {
+			return true
+		}
+		c.visited[v] = true
+
+		if x.Kind() == reflect.Ptr {
+			return c.equal(path, x.Elem(), y.Elem())
+		}
+
+		if x.Len() != y.Len() {
+			return false
+		}
+		for _, k := range x.MapKeys() {
+			yv := y.MapIndex(k)
+			if !yv.IsValid() || !c.equal(path, x.MapIndex(k), yv) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Interface:
+		return c.equal(path, x.Elem(), y.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			f := x.Type().Field(i)
+			p := strings.TrimPrefix(path+"."+f.Name, ".")
+			if c.ignored(f, p) {
+				continue
+			}
+			if !c.equal(p, gop.GetPrivateField(x, i), gop.GetPrivateField(y, i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice, reflect.Array:
+		if x.Len() != y.Len() {
+			return false
+		}
+		for i := 0; i < x.Len(); i++ {
+			if !c.equal(path, x.Index(i), y.Index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func (c *comparison) ignored(f reflect.StructField, path string) bool {
+	if c.ignoreUnexported && !f.IsExported() {
+		return true
+	}
+	for _, name := range c.fields {
+		if name == f.Name || name == path {
+			return true
+		}
+	}
+	return false
+}
+
+var regChangePathSel = regexp.MustCompile(`(?:\.([^.\[]+)|\[(?:"(?:[^"\\]|\\.)*"|[^\]]*)\])`)
+
+// ignoredChange returns true if the structdiff change is at an ignored field or the values are equal under the options
+func (c *comparison) ignoredChange(ch structdiff.Change) bool {
+	path := ""
+	for _, ms := range regChangePathSel.FindAllStringSubmatch(ch.Path, -1) {
+		if name := ms[1]; name != "" {
+			path = strings.TrimPrefix(path+"."+name, ".")
+			if c.ignored(reflect.StructField{Name: name, PkgPath: unexportedPkgPath(name)}, path) {
+				return true
+			}
+		}
+	}
+
+	return ch.Kind == structdiff.Modified && c.equal(path, reflect.ValueOf(ch.X), reflect.ValueOf(ch.Y))
+}
+
+// unexportedPkgPath makes the [reflect.StructField.IsExported] work for the field name
+func unexportedPkgPath(name string) string {
+	if token.IsExported(name) {
+		return ""
+	}
+	return "_"
+}
+
+// filterChanges removes the structdiff changes that are ignored by the options, it's for the report of [Assertions.Eq]
+func filterChanges(changes []structdiff.Change, opts []CompareOption) []structdiff.Change {
+	c := newComparison(false, opts)
+
+	list := []structdiff.Change{}
+	for _, ch := range changes {
+		if !c.ignoredChange(ch) {
+			list = append(list, ch)
+		}
+	}
+	return list
+}
+
+// empty returns true if the v is nil or an empty slice or map when the equateEmpty option is enabled
+func (c *comparison) empty(v reflect.Value) bool {
+	if !c.equateEmpty {
+		return false
+	}
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
@@ -0,0 +1,112 @@
+package got_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+)
+
+type record struct {
+	ID        int
+	Score     float64
+	Tags      []string
+	Meta      map[string]interface{}
+	Next      *record
+	UpdatedAt time.Time
+	secret    string
+}
+
+func TestCompareOptions(t *testing.T) {
+	g := setup(t)
+
+	x := record{ID: 1, Score: 0.1 + 0.2, Meta: map[string]interface{}{"a": 1.0}, UpdatedAt: time.Now(), secret: "x"}
+	y := record{ID: 1, Score: 0.3, Tags: []string{}, Meta: map[string]interface{}{"a": 1}, secret: "y"}
+
+	g.Eq(x, y, got.IgnoreFields("UpdatedAt"), got.FloatTolerance(1e-9), got.IgnoreUnexported(), got.EquateEmpty())
+	g.Eq(&x, &y, got.IgnoreFields("UpdatedAt", "secret", "Tags", "Score"))
+	g.Equal([]record{x}, []record{y}, got.IgnoreFields("UpdatedAt", "secret", "Tags", "Score", "Meta"))
+	g.Eq(map[string][]int{"a": nil}, map[string][]int{"a": {}}, got.EquateEmpty())
+	g.Eq([]interface{}{nil}, []interface{}{[]int{}}, got.EquateEmpty())
+	g.Eq(1, 1.0000001, got.FloatTolerance(1e-6))
+
+	a, b := &record{ID: 1, UpdatedAt: time.Now()}, &record{ID: 1}
+	a.Next, b.Next = a, b
+	g.Eq(a, b, got.IgnoreFields("UpdatedAt"))
+
+	m := &mock{t: t}
+	gm := got.New(m)
+
+	for i, c := range []struct {
+		x, y interface{}
+		opts []got.CompareOption
+	}{
+		{x, y, []got.CompareOption{got.IgnoreFields("UpdatedAt"), got.FloatTolerance(1e-9), got.EquateEmpty()}},
+		{x, y, []got.CompareOption{got.IgnoreFields("Next.UpdatedAt"), got.FloatTolerance(1e-9), got.IgnoreUnexported(), got.EquateEmpty()}},
+		{1, 1.1, []got.CompareOption{got.FloatTolerance(1e-6)}},
+		{1, "1", []got.CompareOption{got.FloatTolerance(1e-6)}},
+		{[]int{1}, []int{1, 2}, []got.CompareOption{got.EquateEmpty()}},
+		{map[int]int{1: 1}, map[int]int{}, []got.CompareOption{got.EquateEmpty()}},
+		{map[int]int{1: 1}, map[int]int{1: 2}, []got.CompareOption{got.EquateEmpty()}},
+		{map[string][]int{"a": {}}, map[string][]int{"b": {}}, []got.CompareOption{got.EquateEmpty()}},
+		{[]int{1}, []int{2}, []got.CompareOption{got.EquateEmpty()}},
+		{&record{}, (*record)(nil), []got.CompareOption{got.EquateEmpty()}},
+		{[]interface{}{nil}, []interface{}{1}, []got.CompareOption{got.EquateEmpty()}},
+		{1, nil, []got.CompareOption{got.IgnoreUnexported()}},
+		{func() {}, func() {}, []got.CompareOption{got.IgnoreUnexported()}},
+	} {
+		gm.Eq(c.x, c.y, c.opts...)
+		g.Desc("case %d", i).True(m.failed)
+		m.reset()
+	}
+
+	gm.Equal(1, 1.0, got.FloatTolerance(1))
+	g.True(m.failed)
+	m.reset()
+}
+
+type version struct {
+	Major int
+	Note  string
+}
+
+// Equal is unrelated to the comparison of version values
+func (v version) Equal(major int) bool { return v.Major == major }
+
+func TestCompareOptionsReport(t *testing.T) {
+	g := setup(t)
+
+	g.Eq(version{1, "a"}, version{1, "b"}, got.IgnoreFields("Note"))
+
+	m := &mock{t: t}
+	gm := got.New(m)
+
+	type item struct {
+		Name  string
+		Score float64
+		Tags  []string
+		At    time.Time
+		v     version
+	}
+	x := item{Name: "a", Score: 0.1 + 0.2, At: time.Now(), v: version{1, "a"}}
+	y := item{Name: "b", Score: 0.3, Tags: []string{}, v: version{1, "b"}}
+
+	gm.Eq(x, y, got.IgnoreFields("At"), got.FloatTolerance(1e-9), got.EquateEmpty(), got.IgnoreUnexported())
+	g.Has(gop.StripANSI(m.msg), `~ .Name: "a" → "b"`)
+	g.Eq(strings.Count(m.msg, "~ ."), 1)
+	m.reset()
+
+	gm.Eq(map[string]item{"k": x}, map[string]item{"k": y}, got.IgnoreFields("Name", "Score", "Tags", "At"))
+	g.Has(gop.StripANSI(m.msg), `~ ["k"].v.Note: "a" → "b"`)
+	g.Eq(strings.Count(m.msg, "~ ["), 1)
+	m.reset()
+
+	// the full report is kept when all the listed changes are ignored
+	type pair struct{ A, B interface{} }
+	gm.Equal(pair{1, 0.1 + 0.2}, pair{1.0, 0.3}, got.FloatTolerance(1e-9))
+	g.Has(m.msg, "not ==")
+	g.False(strings.Contains(m.msg, "~ ."))
+	m.reset()
+}
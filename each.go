@@ -1,8 +1,13 @@
 package got
 
 import (
+	"math/rand"
 	"reflect"
 	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Only run tests with it
@@ -11,6 +16,26 @@ type Only struct{}
 // Skip the current test
 type Skip struct{}
 
+// Tag of the method of [Each], the Tag method returns the name of the tag.
+// Use the param types that implement it to tag the method, such as:
+//
+//	type Slow struct{}
+//
+//	func (Slow) Tag() string { return "slow" }
+//
+//	func (ctx Ctx) Fn(Slow) {}
+//
+// Then use the "-got.tags" flag or the "GOT_TAGS" env to select the methods by the tags separated by comma,
+// the "-" prefix excludes the tag, such as "-got.tags=db,-slow" runs the methods tagged "db" but not "slow".
+// When no tag is included, all the methods that are not excluded will run.
+type Tag interface {
+	Tag() string
+}
+
+// Concurrent runs the current test in parallel with the other concurrent tests, like [testing.T.Parallel].
+// It's named Concurrent because [Parallel] is taken.
+type Concurrent struct{}
+
 // Each runs each exported method Fn on type Ctx as a sub test of t.
 // The iteratee can be a struct Ctx or:
 //
@@ -20,8 +45,26 @@ type Skip struct{}
 //
 //	ctx.Fn()
 //
+// The params of Fn can be the markers [Only], [Skip], [Concurrent], or [Tag], such as:
+//
+//	func (ctx Ctx) Fn(got.Only, got.Concurrent) {}
+//
+// Each Fn gets its own Ctx created by the iteratee, so it's safe to use [Concurrent].
 // If iteratee is Ctx, its G field will be set to New(t) for each test.
 // Any Fn that has the same name with the embedded one will be ignored.
+// The Fn run in alphabetical order, use the "-got.shuffle" flag or the "GOT_SHUFFLE" env to randomize the order,
+// it can be "on" or an integer seed, the seed will be logged to reproduce the order.
+//
+// The lifecycle hooks on Ctx are not tests, they will be called if they exist:
+//
+//	BeforeAll(G)  // before all the tests, the G is for t
+//	AfterAll(G)   // after all the tests and their cleanups, the G is for t
+//	BeforeEach(G) // before each test, the G is for the sub test, if it panics the test fails without running
+//	AfterEach(G)  // after each test, even if the test panics, the G is for the sub test
+//
+// The G params of the hooks are optional.
+// The receiver of BeforeAll and AfterAll is the struct iteratee or the zero value of Ctx if iteratee is a function,
+// because the function is designed to init the context for each test, such as calling [G.Parallel].
 func Each(t Testable, iteratee interface{}) (count int) {
 	t.Helper()
 
@@ -31,6 +74,27 @@ func Each(t Testable, iteratee interface{}) (count int) {
 
 	methods := filterMethods(ctxType)
 
+	shuffle(t, len(methods), func(i, j int) { methods[i], methods[j] = methods[j], methods[i] })
+
+	hooks := reflect.Zero(ctxType)
+	if reflect.ValueOf(iteratee).Kind() == reflect.Struct {
+		hooks = reflect.ValueOf(iteratee)
+	}
+
+	// the parent test may have its own G, so don't prune the snapshots of it
+	g := newG(t)
+
+	if !callHook(t, hooks, "BeforeAll", g) {
+		return
+	}
+
+	if _, has := ctxType.MethodByName("AfterAll"); has {
+		t.Cleanup(func() { callHook(t, hooks, "AfterAll", g) })
+	}
+
+	// the concurrent tests count themselves before they pause, so it's safe to read after the loop
+	var n int64
+
 	runVal := reflect.ValueOf(t).MethodByName("Run")
 	cbType := runVal.Type().In(1)
 
@@ -43,13 +107,14 @@ func Each(t Testable, iteratee interface{}) (count int) {
 			reflect.MakeFunc(cbType, func(args []reflect.Value) []reflect.Value {
 				t := args[0].Interface().(Testable)
 				doSkip(t, method)
-				count++
+				atomic.AddInt64(&n, 1)
+				doParallel(t, method)
 				res := itVal.Call(args)
 				return callMethod(t, method, res[0])
 			}),
 		})
 	}
-	return
+	return int(atomic.LoadInt64(&n))
 }
 
 func normalizeIteratee(t Testable, iteratee interface{}) reflect.Value {
@@ -105,6 +170,11 @@ func callMethod(t Testable, method reflect.Method, receiver reflect.Value) []ref
 		args[i] = reflect.New(method.Type.In(i)).Elem()
 	}
 
+	// the G of the sub test for the hooks, the receiver may not have a G
+	g := newG(t)
+
+	defer callHook(t, receiver, "AfterEach", g)
+
 	defer func() {
 		if err := recover(); err != nil {
 			t.Logf("[panic] %v\n%s", err, debug.Stack())
@@ -112,11 +182,45 @@ func callMethod(t Testable, method reflect.Method, receiver reflect.Value) []ref
 		}
 	}()
 
-	method.Func.Call(args)
+	if callHook(t, receiver, "BeforeEach", g) {
+		method.Func.Call(args)
+	}
 
 	return []reflect.Value{}
 }
 
+// hookNames are the lifecycle hooks of [Each], they are not tests
+var hookNames = map[string]bool{"BeforeAll": true, "AfterAll": true, "BeforeEach": true, "AfterEach": true}
+
+// callHook calls the hook method of receiver if it exists, the params of type G will be set to g.
+// It returns false if the hook panics, the panic will be logged as a failure.
+func callHook(t Testable, receiver reflect.Value, name string, g G) (ok bool) {
+	hook := receiver.MethodByName(name)
+	if !hook.IsValid() {
+		return true
+	}
+
+	args := make([]reflect.Value, hook.Type().NumIn())
+	for i := range args {
+		if hook.Type().In(i) == reflect.TypeOf(g) {
+			args[i] = reflect.ValueOf(g)
+		} else {
+			args[i] = reflect.New(hook.Type().In(i)).Elem()
+		}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			t.Logf("[panic] %s: %v\n%s", name, err, debug.Stack())
+			t.Fail()
+		}
+	}()
+
+	hook.Call(args)
+
+	return true
+}
+
 func filterMethods(typ reflect.Type) []reflect.Method {
 	embedded := map[string]struct{}{}
 	for i := 0; i < typ.NumField(); i++ {
@@ -128,15 +232,17 @@ func filterMethods(typ reflect.Type) []reflect.Method {
 		}
 	}
 
+	include, exclude := selectedTags()
+
 	methods := []reflect.Method{}
 	onlyList := []reflect.Method{}
 	for i := 0; i < typ.NumMethod(); i++ {
 		method := typ.Method(i)
-		if _, has := embedded[method.Name]; has {
+		if _, has := embedded[method.Name]; has || hookNames[method.Name] || !selectTags(method, include, exclude) {
 			continue
 		}
 
-		if method.Type.NumIn() > 1 && method.Type.In(1) == reflect.TypeOf(Only{}) {
+		if hasMarker(method, Only{}) {
 			onlyList = append(onlyList, method)
 		}
 
@@ -151,11 +257,92 @@ func filterMethods(typ reflect.Type) []reflect.Method {
 }
 
 func doSkip(t Testable, method reflect.Method) {
-	if method.Type.NumIn() > 1 && method.Type.In(1) == reflect.TypeOf(Skip{}) {
+	if hasMarker(method, Skip{}) {
 		t.SkipNow()
 	}
 }
 
+// doParallel calls the Parallel method of t if the method has the [Concurrent] marker and t supports it
+func doParallel(t Testable, method reflect.Method) {
+	if !hasMarker(method, Concurrent{}) {
+		return
+	}
+
+	tryParallel(t)
+}
+
+// tryParallel calls the Parallel method of t if t supports it
+func tryParallel(t Testable) {
+	if p := reflect.ValueOf(t).MethodByName("Parallel"); p.IsValid() {
+		p.Call(nil)
+	}
+}
+
+// selectTags returns false if the method has an excluded tag or it has none of the included tags
+func selectTags(method reflect.Method, include, exclude map[string]bool) bool {
+	tagType := reflect.TypeOf((*Tag)(nil)).Elem()
+
+	included := len(include) == 0
+	for i := 1; i < method.Type.NumIn(); i++ {
+		param := method.Type.In(i)
+		if param.Kind() == reflect.Interface || !param.Implements(tagType) {
+			continue
+		}
+
+		tag := reflect.New(param).Elem().Interface().(Tag).Tag()
+		if exclude[tag] {
+			return false
+		}
+		included = included || include[tag]
+	}
+	return included
+}
+
+// hasMarker returns true if any param of the method is the type of marker
+func hasMarker(method reflect.Method, marker interface{}) bool {
+	for i := 1; i < method.Type.NumIn(); i++ {
+		if method.Type.In(i) == reflect.TypeOf(marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// shuffleSeed is the seed of "-got.shuffle=on", it's shared by all the tests of the process,
+// so that the logged seed can reproduce the order of all of them.
+var shuffleSeed struct {
+	once sync.Once
+	val  int64
+}
+
+// shuffle the order of the n tests if the "-got.shuffle" flag or the "GOT_SHUFFLE" env is set, it logs the seed,
+// the random seed of "on" is only logged by the first shuffled test.
+func shuffle(t Testable, n int, swap func(i, j int)) {
+	t.Helper()
+
+	var seed int64
+	switch val := flagOrEnvString(flagShuffle, "GOT_SHUFFLE"); val {
+	case "", "off":
+		return
+	case "on":
+		shuffleSeed.once.Do(func() {
+			shuffleSeed.val = time.Now().UnixNano()
+			t.Logf("-got.shuffle=%d", shuffleSeed.val)
+		})
+		seed = shuffleSeed.val
+	default:
+		var err error
+		seed, err = strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			t.Logf(`-got.shuffle should be "off", "on", or an integer seed, but got %q`, val)
+			t.FailNow()
+		}
+		t.Logf("-got.shuffle=%d", seed)
+	}
+
+	rand.New(rand.NewSource(seed)).Shuffle(n, swap)
+}
+
 func try(fn func()) {
 	defer func() {
 		_ = recover()
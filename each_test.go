@@ -1,7 +1,9 @@
 package got_test
 
 import (
+	"flag"
 	"testing"
+	"time"
 
 	"github.com/ysmood/got"
 )
@@ -102,3 +104,183 @@ func (p PanicAsFailure) A() {
 
 func (p PanicAsFailure) B() {
 }
+
+func TestEachHooks(t *testing.T) {
+	as := got.New(t)
+	t.Setenv("GOT_SHUFFLE", "off")
+
+	m := &mock{t: t}
+	log := []string{}
+	as.Eq(got.Each(m, Hooks{log: &log}), 2)
+	m.cleanup()
+
+	as.Eq(log, []string{
+		"before all", "before each", "a", "after each",
+		"before each", "after each", "after all",
+	})
+	as.True(m.failed)
+	as.Has(m.msg, "[panic] b")
+}
+
+type Hooks struct {
+	got.G
+	log *[]string
+}
+
+func (h Hooks) BeforeAll(g got.G) {
+	g.NotZero(g.Testable)
+	*h.log = append(*h.log, "before all")
+}
+
+func (h Hooks) AfterAll(got.G) { *h.log = append(*h.log, "after all") }
+func (h Hooks) BeforeEach()    { *h.log = append(*h.log, "before each") }
+func (h Hooks) AfterEach()     { *h.log = append(*h.log, "after each") }
+func (h Hooks) A()             { *h.log = append(*h.log, "a") }
+func (h Hooks) B()             { panic("b") }
+
+func TestEachHookPanic(t *testing.T) {
+	as := got.New(t)
+
+	m := &mock{t: t}
+	it := func(_ *mock) HookPanic { return HookPanic{} }
+	as.Eq(got.Each(m, it), 0)
+	as.True(m.failed)
+	as.Has(m.msg, "[panic] BeforeAll: before all")
+
+	m = &mock{t: t}
+	as.Eq(got.Each(m, HookEachPanic{}), 1)
+	as.True(m.failed)
+	as.Has(m.msg, "[panic] BeforeEach: before each")
+}
+
+func TestEachHookG(t *testing.T) {
+	as := got.New(t)
+	t.Setenv("GOT_SHUFFLE", "off")
+
+	m := &mock{t: t}
+	as.Eq(got.Each(m, HookG{}), 1)
+	as.False(m.failed)
+	as.Eq(m.msg, "before each\nafter each")
+}
+
+type HookG struct{}
+
+func (HookG) BeforeEach(g got.G) { g.Logf("before each") }
+func (HookG) AfterEach(g got.G)  { g.Logf("after each") }
+func (HookG) A()                 {}
+
+type HookPanic struct{}
+
+func (HookPanic) BeforeAll(int) { panic("before all") }
+func (HookPanic) A()            {}
+
+type HookEachPanic struct{ got.G }
+
+func (HookEachPanic) BeforeEach() { panic("before each") }
+func (h HookEachPanic) A()        { h.Fail() }
+
+func TestEachConcurrent(t *testing.T) {
+	g := got.New(t)
+
+	g.Eq(got.Each(t, Concurrent{wait: make(chan struct{})}), 2)
+
+	m := &mock{t: t}
+	g.Eq(got.Each(m, ConcurrentOnly{}), 1)
+}
+
+type Concurrent struct {
+	got.G
+	wait chan struct{}
+}
+
+// A will timeout if A and B are not run concurrently
+func (c Concurrent) A(got.Concurrent) {
+	select {
+	case <-c.wait:
+	case <-c.Timeout(time.Second).Done():
+		c.Fatal("not concurrent")
+	}
+}
+
+func (c Concurrent) B(got.Concurrent) {
+	close(c.wait)
+}
+
+type ConcurrentOnly struct{}
+
+func (ConcurrentOnly) A(got.Concurrent, got.Only) {}
+func (ConcurrentOnly) B()                         { panic("should be skipped") }
+
+func TestEachTags(t *testing.T) {
+	g := got.T(t)
+	t.Setenv("GOT_SHUFFLE", "off")
+
+	run := func(tags string) []string {
+		t.Setenv("GOT_TAGS", tags)
+		list := []string{}
+		got.Each(&mock{t: t}, Tagged{list: &list})
+		return list
+	}
+
+	g.Eq(run(""), []string{"A", "B", "C", "D"})
+	g.Eq(run("db"), []string{"B", "C"})
+	g.Eq(run("db, -slow"), []string{"B"})
+	g.Eq(run("-slow"), []string{"A", "B", "D"})
+	g.Eq(run("slow,other"), []string{"C"})
+
+	g.E(flag.Set("got.tags", "-db"))
+	defer func() { _ = flag.Set("got.tags", "") }()
+	g.Eq(run("slow"), []string{"A", "D"})
+}
+
+type Tagged struct {
+	list *[]string
+}
+
+type DB struct{}
+
+func (DB) Tag() string { return "db" }
+
+type Slow struct{}
+
+func (Slow) Tag() string { return "slow" }
+
+func (c Tagged) A()                  { *c.list = append(*c.list, "A") }
+func (c Tagged) B(DB)                { *c.list = append(*c.list, "B") }
+func (c Tagged) C(Slow, DB)          { *c.list = append(*c.list, "C") }
+func (c Tagged) D(got.Tag, got.Skip) { *c.list = append(*c.list, "D") }
+
+func TestEachShuffle(t *testing.T) {
+	g := got.T(t)
+
+	m := &mock{t: t}
+	run := func(shuffle string) []string {
+		t.Setenv("GOT_SHUFFLE", shuffle)
+		m.reset()
+		list := []string{}
+		got.Each(m, Tagged{list: &list})
+		return list
+	}
+
+	g.Eq(run("off"), []string{"A", "B", "C", "D"})
+	g.Eq(run("1"), []string{"A", "B", "D", "C"})
+	g.Eq(m.msg, "-got.shuffle=1")
+	g.Eq(run("1"), run("1"))
+
+	// the seed of "on" is picked and logged once per process
+	g.Eq(run("on"), run("on"))
+	g.Eq(m.msg, "")
+
+	g.Panic(func() { run("x") })
+	m.check(`-got.shuffle should be "off", "on", or an integer seed, but got "x"`)
+
+	g.E(flag.Set("got.shuffle", "2"))
+	defer func() { _ = flag.Set("got.shuffle", "") }()
+	g.Eq(run("1"), []string{"B", "C", "D", "A"})
+
+	cases := []int{1, 2, 3, 4}
+	list := []int{}
+	got.Table(t, cases, func(_ got.G, c int) { list = append(list, c) })
+	g.Eq(list, []int{2, 3, 4, 1})
+	g.Eq(cases, []int{1, 2, 3, 4})
+}
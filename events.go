@@ -0,0 +1,93 @@
+package got
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ysmood/gop"
+)
+
+// AssertionEventPrefix is the prefix of the log line of an [AssertionEvent] when the events are emitted to the test log,
+// such as "got-event: {...}". It's a stable prefix for the tools to find the events in the "go test -json" output.
+const AssertionEventPrefix = "got-event: "
+
+// AssertionEvent is the machine-readable record of a failed assertion. Its json schema is stable,
+// the new fields will only be appended.
+// Use the "-got.events" flag or the "GOT_EVENTS" env to emit the events, if the value is "log" the event will be logged
+// to the test output with the [AssertionEventPrefix], otherwise the value is the path of the file to append
+// the events as JSON lines. The relative path is resolved against the package dir of the test files, so use an
+// absolute path to collect the events of multiple packages.
+// The intermediate failures inside [Assertions.Eventually], [Assertions.Consistently], and [Assertions.Group]
+// are not emitted, only the final failure of them is.
+type AssertionEvent struct {
+	// Type is the name of the [AssertionErrType], such as "Eq"
+	Type string `json:"type"`
+	// Test is the name of the test
+	Test string `json:"test"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Source is the source code of the assertion call, it's empty if the source file is not available
+	Source string `json:"source,omitempty"`
+	// Desc is the stack of the descriptions set by [Assertions.Desc]
+	Desc []string `json:"desc,omitempty"`
+	// Details are the values of [AssertionCtx.Details] formatted by gop
+	Details []string `json:"details"`
+	// Report is the plain text of the assertion error report
+	Report string `json:"report"`
+}
+
+var eventsLock sync.Mutex
+
+func (as Assertions) emitEvent(c *AssertionCtx, report string) {
+	as.Helper()
+
+	// the failures inside Eventually, Consistently, or Group are not final,
+	// their reports will be carried by the event of the outer assertion
+	if _, nested := as.Testable.(*attempt); nested {
+		return
+	}
+
+	target := flagOrEnvString(flagEvents, "GOT_EVENTS")
+	if target == "" {
+		return
+	}
+
+	details := []string{}
+	for _, d := range c.Details {
+		details = append(details, gop.Plain(d))
+	}
+
+	data, _ := json.Marshal(AssertionEvent{ // all the fields are json friendly, it won't fail
+		Type:    c.Type.String(),
+		Test:    as.Name(),
+		File:    c.File,
+		Line:    c.Line,
+		Source:  c.Source,
+		Desc:    as.desc,
+		Details: details,
+		Report:  gop.StripANSI(report),
+	})
+
+	if target == "log" {
+		as.Logf("%s%s", AssertionEventPrefix, data)
+		return
+	}
+
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(initWd, target)
+	}
+
+	eventsLock.Lock()
+	defer eventsLock.Unlock()
+
+	f, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		as.Logf("failed to emit the assertion event: %v", err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	_, _ = f.Write(append(data, '\n'))
+}
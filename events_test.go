@@ -0,0 +1,103 @@
+package got_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ysmood/got"
+)
+
+func TestAssertionEvents(t *testing.T) {
+	g := got.T(t)
+
+	m := &mock{t: t, name: "TestEvents"}
+	gm := got.New(m)
+
+	t.Setenv("GOT_EVENTS", "log")
+	gm.Desc("desc").Eq(1, 2)
+	g.Has(m.msg, got.AssertionEventPrefix)
+
+	var e got.AssertionEvent
+	g.E(json.Unmarshal([]byte(m.msg[strings.Index(m.msg, got.AssertionEventPrefix)+len(got.AssertionEventPrefix):]), &e))
+	m.reset()
+
+	g.Eq(e, got.AssertionEvent{
+		Type:    "Eq",
+		Test:    "TestEvents",
+		File:    e.File,
+		Line:    e.Line,
+		Source:  `gm.Desc("desc").Eq(1, 2)`,
+		Desc:    []string{"desc"},
+		Details: []string{"1", "2"},
+		Report:  "1 ⦗not ==⦘ 2",
+	})
+	g.Eq(filepath.Base(e.File), "events_test.go")
+
+	wd, err := os.Getwd()
+	g.E(err)
+	path, err := filepath.Rel(wd, filepath.Join(t.TempDir(), "events.jsonl"))
+	g.E(err)
+
+	t.Setenv("GOT_EVENTS", "")
+	g.E(flag.Set("got.events", path))
+	defer func() { _ = flag.Set("got.events", "") }()
+	n := 1
+	gm.True(false)
+	gm.Eq(n, 2)
+	m.reset()
+
+	lines := strings.Split(strings.TrimSpace(g.Read(filepath.Join(wd, path)).String()), "\n")
+	g.Len(lines, 2)
+	g.E(json.Unmarshal([]byte(lines[1]), &e))
+	g.Eq(e.Source, "gm.Eq(n, 2)")
+
+	g.E(flag.Set("got.events", t.TempDir()))
+	gm.True(false)
+	g.Has(m.msg, "failed to emit the assertion event")
+	m.reset()
+}
+
+func TestAssertionEventsNested(t *testing.T) {
+	g := got.T(t)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	t.Setenv("GOT_EVENTS", path)
+
+	n := 0
+	gm.Eventually(time.Second, time.Millisecond, func(g got.G) {
+		n++
+		g.True(n >= 3)
+	})
+	g.False(m.failed)
+	g.False(g.PathExists(path))
+
+	gm.Group(func(g got.G) {
+		g.True(false)
+		g.Eq(1, 2)
+	})
+	m.reset()
+
+	lines := strings.Split(strings.TrimSpace(g.Read(path).String()), "\n")
+	g.Len(lines, 1)
+
+	var e got.AssertionEvent
+	g.E(json.Unmarshal([]byte(lines[0]), &e))
+	g.Eq(e.Type, "Group")
+	g.Has(e.Report, "1 ⦗not ==⦘ 2")
+}
+
+func TestAssertionErrTypeString(t *testing.T) {
+	g := setup(t)
+
+	g.Eq(got.AssertionEq.String(), "Eq")
+	g.Eq(got.AssertionGroup.String(), "Group")
+	g.Eq(got.AssertionErrType(-1).String(), "AssertionErrType(-1)")
+}
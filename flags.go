@@ -0,0 +1,59 @@
+package got
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var flagUpdate = flag.Bool("got.update", false, "update the mismatched snapshots, same as env GOT_UPDATE=1")
+
+var flagStrict = flag.Bool("got.strict", false, "fail the test when a snapshot is missing or unused, it's auto-enabled by env CI=true")
+
+var flagTags = flag.String("got.tags", "", `select the methods of got.Each by tags separated by comma, the "-" prefix excludes a tag, such as "db,-slow", same as env GOT_TAGS`)
+
+var flagShuffle = flag.String("got.shuffle", "", `randomize the order of the methods of got.Each and the cases of got.Table, "off" by default, "on", or an integer seed, same as env GOT_SHUFFLE`)
+
+var flagEvents = flag.String("got.events", "", `emit the failed assertions as json events to the file path, or to the test log if it's "log", same as env GOT_EVENTS`)
+
+// flagOrEnvBool returns true if the flag is true or the env is a true value, such as "1" or "true".
+func flagOrEnvBool(flag *bool, env string) bool {
+	if *flag {
+		return true
+	}
+
+	b, _ := strconv.ParseBool(os.Getenv(env))
+	return b
+}
+
+func snapshotUpdate() bool {
+	return flagOrEnvBool(flagUpdate, "GOT_UPDATE")
+}
+
+// snapshotStrict mode is ignored when the update mode is enabled
+func snapshotStrict() bool {
+	return flagOrEnvBool(flagStrict, "CI") && !snapshotUpdate()
+}
+
+// flagOrEnvString returns the flag if it's not empty, or the env
+func flagOrEnvString(flag *string, env string) string {
+	if *flag != "" {
+		return *flag
+	}
+	return os.Getenv(env)
+}
+
+// selectedTags returns the included and excluded tags of the "-got.tags" flag or the "GOT_TAGS" env
+func selectedTags() (include, exclude map[string]bool) {
+	include, exclude = map[string]bool{}, map[string]bool{}
+	for _, tag := range strings.Split(flagOrEnvString(flagTags, "GOT_TAGS"), ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "-") {
+			exclude[tag[1:]] = true
+		} else if tag != "" {
+			include[tag] = true
+		}
+	}
+	return
+}
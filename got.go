@@ -7,7 +7,6 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
-	"sync"
 
 	"github.com/ysmood/gop"
 	"github.com/ysmood/got/lib/diff"
@@ -32,7 +31,7 @@ type G struct {
 	Assertions
 	Utils
 
-	snapshots *sync.Map
+	snapshots *snapshots
 }
 
 // Setup returns a helper to init G instance
@@ -53,18 +52,22 @@ func T(t Testable) G {
 
 // New G instance
 func New(t Testable) G {
+	g := newG(t)
+	g.pruneSnapshots()
+	return g
+}
+
+// newG creates G without the auto-removal of the unused snapshots, it's for the nested G
+// that shares the same test with another G.
+func newG(t Testable) G {
 	eh := NewDefaultAssertionError(15, gop.ThemeDefault, diff.ThemeDefault)
 
-	g := G{
+	return G{
 		t,
 		Assertions{Testable: t, ErrorHandler: eh},
 		Utils{t},
-		&sync.Map{},
+		newSnapshots(t.Name()),
 	}
-
-	g.loadSnapshots()
-
-	return g
 }
 
 // DefaultFlags will set the "go test" flag if not yet presented.
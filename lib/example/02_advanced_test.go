@@ -42,6 +42,18 @@ func TestTableDriven(t *testing.T) {
 	}
 }
 
+func TestTable(t *testing.T) {
+	type testCase struct{ Name, A, B, Expected string }
+
+	// Same as the TestTableDriven, the Name field is used as the name of the sub test.
+	got.Table(t, []testCase{
+		{"first", "1", "2", "3"},
+		{"second", "2", "3", "5"},
+	}, func(g got.G, c testCase) {
+		g.Eq(example.Sum(c.A, c.B), c.Expected)
+	})
+}
+
 func TestSnapshot(t *testing.T) {
 	g := setup(t)
 
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/ysmood/got"
 	"github.com/ysmood/got/lib/example"
 	"github.com/ysmood/got/lib/mock"
 )
@@ -50,7 +51,7 @@ func TestMocking(t *testing.T) {
 
 	// We can use the Calls helper to get all the input and output history of a method.
 	// Check the lib/example/.got/snapshots/TestMocking/calls.gop file for the details.
-	g.Snapshot("calls", m.Calls(m.Write))
+	g.Snapshot("calls", m.Calls(m.Write), got.SnapshotGop)
 	g.Desc("the Write should be called twice").Len(m.Calls(m.Write), 2)
 }
 
@@ -0,0 +1,258 @@
+// Package structdiff compares two values structurally via reflection and reports the changes with their paths,
+// such as `.Users[3].Email: "a" → "b"`.
+package structdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got/lib/diff"
+	"github.com/ysmood/got/lib/utils"
+)
+
+// Kind of the change
+type Kind int
+
+const (
+	// Modified means the value at the path is changed from X to Y
+	Modified Kind = iota
+	// Added means the value at the path only exists in Y
+	Added
+	// Removed means the value at the path only exists in X
+	Removed
+)
+
+// Change at the path
+type Change struct {
+	Kind Kind
+	Path string
+	X    interface{}
+	Y    interface{}
+}
+
+// LCSLimit is the max product of the lengths of two sequences to detect the insertions and deletions,
+// the longer sequences will be compared item by item.
+var LCSLimit = 1 << 20
+
+// Compare x and y, returns the changes that turn x into y.
+// Struct fields, map keys, and slice items are compared recursively,
+// the insertions and deletions of slice items are detected via the longest common subsequence.
+// The values whose type has the compare method, such as [time.Time], are compared as a whole
+// via [utils.SmartCompare], see [utils.HasCompareMethod].
+func Compare(x, y interface{}) []Change {
+	c := &comparer{visited: map[visit]bool{}}
+	c.compare("", reflect.ValueOf(x), reflect.ValueOf(y))
+	return c.changes
+}
+
+type visit struct {
+	x, y uintptr
+	t    reflect.Type
+}
+
+type comparer struct {
+	changes []Change
+	visited map[visit]bool
+}
+
+func (c *comparer) add(kind Kind, path string, x, y reflect.Value) {
+	c.changes = append(c.changes, Change{kind, path, value(x), value(y)})
+}
+
+func (c *comparer) compare(path string, x, y reflect.Value) {
+	if !x.IsValid() || !y.IsValid() || x.Type() != y.Type() {
+		if x.IsValid() || y.IsValid() {
+			c.add(Modified, path, x, y)
+		}
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if x.IsNil() || y.IsNil() {
+			if x.IsNil() != y.IsNil() {
+				c.add(Modified, path, x, y)
+			}
+			return
+		}
+
+		if x.Pointer() == y.Pointer() && (x.Kind() == reflect.Ptr || x.Len() == y.Len()) {
+			return
+		}
+
+		if x.Kind() != reflect.Slice {
+			v := visit{x.Pointer(), y.Pointer(), x.Type()}
+			if c.visited[v] {
+				return
+			}
+			c.visited[v] = true
+		}
+	}
+
+	// the type defines its own equality, such as time.Time and *big.Int, its internals are not meaningful to report
+	if utils.HasCompareMethod(x.Type()) {
+		if utils.SmartCompare(x.Interface(), y.Interface()) != 0 {
+			c.add(Modified, path, x, y)
+		}
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr:
+		c.compare(path, x.Elem(), y.Elem())
+
+	case reflect.Interface:
+		c.compare(path, x.Elem(), y.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			c.compare(path+"."+x.Type().Field(i).Name, gop.GetPrivateField(x, i), gop.GetPrivateField(y, i))
+		}
+
+	case reflect.Map:
+		c.compareMap(path, x, y)
+
+	case reflect.Slice, reflect.Array:
+		c.compareSeq(path, x, y)
+
+	default:
+		if !reflect.DeepEqual(x.Interface(), y.Interface()) {
+			c.add(Modified, path, x, y)
+		}
+	}
+}
+
+func (c *comparer) compareMap(path string, x, y reflect.Value) {
+	keys := x.MapKeys()
+	for _, k := range y.MapKeys() {
+		if !x.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	for _, k := range keys {
+		p := path + mapKey(k)
+		xv, yv := x.MapIndex(k), y.MapIndex(k)
+		switch {
+		case !yv.IsValid():
+			c.add(Removed, p, xv, yv)
+		case !xv.IsValid():
+			c.add(Added, p, xv, yv)
+		default:
+			c.compare(p, xv, yv)
+		}
+	}
+}
+
+func (c *comparer) compareSeq(path string, x, y reflect.Value) {
+	idx := func(i int) string { return path + "[" + strconv.Itoa(i) + "]" }
+
+	i, j := 0, 0
+	for _, p := range append(lcs(x, y), [2]int{x.Len(), y.Len()}) {
+		for ; i < p[0] && j < p[1]; i, j = i+1, j+1 {
+			c.compare(idx(i), x.Index(i), y.Index(j))
+		}
+		for ; i < p[0]; i++ {
+			c.add(Removed, idx(i), x.Index(i), reflect.Value{})
+		}
+		for ; j < p[1]; j++ {
+			c.add(Added, idx(j), reflect.Value{}, y.Index(j))
+		}
+		i, j = p[0]+1, p[1]+1
+	}
+}
+
+// lcs returns the index pairs of the longest common subsequence of x and y
+func lcs(x, y reflect.Value) [][2]int {
+	n, m := x.Len(), y.Len()
+	if n*m > LCSLimit {
+		return nil
+	}
+
+	// table[i][j] is the length of the lcs of x[i:] and y[j:]
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+
+	eq := func(i, j int) bool {
+		return reflect.DeepEqual(x.Index(i).Interface(), y.Index(j).Interface())
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j) {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	pairs := [][2]int{}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case eq(i, j):
+			pairs = append(pairs, [2]int{i, j})
+			i, j = i+1, j+1
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+func mapKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return "[" + strconv.Quote(k.String()) + "]"
+	}
+	return fmt.Sprintf("[%v]", k.Interface())
+}
+
+func value(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// Format the changes into a human readable string, one change per line, such as:
+//
+//	~ .Users[3].Email: "a" → "b"
+//	+ .Tags["x"]: 1
+//	- .Tags["y"]: 2
+func Format(changes []Change, theme diff.Theme) string {
+	lines := []string{}
+	for _, c := range changes {
+		path := c.Path
+		if path == "" {
+			path = "."
+		}
+
+		var line string
+		switch c.Kind {
+		case Added:
+			line = gop.Stylize("+", theme(diff.AddSymbol)) + " " + path + ": " +
+				gop.Stylize(gop.Plain(c.Y), theme(diff.AddWords))
+		case Removed:
+			line = gop.Stylize("-", theme(diff.DelSymbol)) + " " + path + ": " +
+				gop.Stylize(gop.Plain(c.X), theme(diff.DelWords))
+		default:
+			line = "~ " + path + ": " + gop.Stylize(gop.Plain(c.X), theme(diff.DelWords)) +
+				" → " + gop.Stylize(gop.Plain(c.Y), theme(diff.AddWords))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
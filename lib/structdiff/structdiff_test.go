@@ -0,0 +1,122 @@
+package structdiff_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ysmood/gop"
+	"github.com/ysmood/got"
+	"github.com/ysmood/got/lib/diff"
+	"github.com/ysmood/got/lib/structdiff"
+)
+
+var setup = got.Setup(func(g got.G) {
+	g.ErrorHandler = got.NewDefaultAssertionError(15, nil, nil)
+})
+
+type user struct {
+	Name  string
+	Email string
+	Tags  map[string]int
+	Next  *user
+	Any   interface{}
+	items []int
+}
+
+func TestCompare(t *testing.T) {
+	g := setup(t)
+
+	x := user{
+		Name:  "a",
+		Email: "a@x.com",
+		Tags:  map[string]int{"a": 1, "b": 2},
+		Next:  &user{Name: "n"},
+		Any:   1,
+		items: []int{1, 2, 3, 4},
+	}
+	y := user{
+		Name:  "a",
+		Email: "b@x.com",
+		Tags:  map[string]int{"b": 3, "c": 4},
+		Next:  &user{Name: "m"},
+		Any:   "1",
+		items: []int{0, 1, 3, 4, 5},
+	}
+
+	g.Eq(structdiff.Compare(x, y), []structdiff.Change{
+		{structdiff.Modified, ".Email", "a@x.com", "b@x.com"},
+		{structdiff.Removed, `.Tags["a"]`, 1, nil},
+		{structdiff.Modified, `.Tags["b"]`, 2, 3},
+		{structdiff.Added, `.Tags["c"]`, nil, 4},
+		{structdiff.Modified, ".Next.Name", "n", "m"},
+		{structdiff.Modified, ".Any", 1, "1"},
+		{structdiff.Added, ".items[0]", nil, 0},
+		{structdiff.Removed, ".items[1]", 2, nil},
+		{structdiff.Added, ".items[4]", nil, 5},
+	})
+
+	g.Len(structdiff.Compare(x, x), 0)
+	g.Len(structdiff.Compare(&x, &x), 0)
+	g.Len(structdiff.Compare(nil, nil), 0)
+	g.Len(structdiff.Compare(map[int]int(nil), map[int]int(nil)), 0)
+
+	g.Eq(structdiff.Compare(map[int][]int{1: nil}, map[int][]int{1: {}}), []structdiff.Change{
+		{structdiff.Modified, "[1]", []int(nil), []int{}},
+	})
+
+	g.Eq(structdiff.Compare([2]int{1, 2}, [2]int{1, 3}), []structdiff.Change{
+		{structdiff.Modified, "[1]", 2, 3},
+	})
+
+	a, b := &user{}, &user{}
+	a.Next, b.Next = a, b
+	g.Len(structdiff.Compare(a, b), 0)
+}
+
+func TestCompareLeaf(t *testing.T) {
+	g := setup(t)
+
+	type event struct {
+		At  time.Time
+		Num *big.Int
+	}
+
+	x := event{time.Unix(1, 0), big.NewInt(1)}
+	y := event{time.Unix(2, 0), big.NewInt(2)}
+
+	g.Eq(structdiff.Compare(x, y), []structdiff.Change{
+		{structdiff.Modified, ".At", x.At, y.At},
+		{structdiff.Modified, ".Num", x.Num, y.Num},
+	})
+
+	g.Len(structdiff.Compare(x, event{time.Unix(1, 0), big.NewInt(1)}), 0)
+}
+
+func TestCompareLCSLimit(t *testing.T) {
+	g := setup(t)
+
+	old := structdiff.LCSLimit
+	structdiff.LCSLimit = 1
+	defer func() { structdiff.LCSLimit = old }()
+
+	g.Eq(structdiff.Compare([]int{1, 2}, []int{2}), []structdiff.Change{
+		{structdiff.Modified, "[0]", 1, 2},
+		{structdiff.Removed, "[1]", 2, nil},
+	})
+}
+
+func TestFormat(t *testing.T) {
+	g := setup(t)
+
+	out := structdiff.Format(structdiff.Compare(
+		map[string]interface{}{"a": 1, "b": 2},
+		map[string]interface{}{"b": "2", "c": 3},
+	), diff.ThemeNone)
+
+	g.Eq(gop.StripANSI(out), `- ["a"]: 1
+~ ["b"]: 2 → "2"
++ ["c"]: 3`)
+
+	g.Eq(structdiff.Format(structdiff.Compare(1, 2), diff.ThemeNone), "~ .: 1 → 2")
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"reflect"
+	"sync"
+)
+
+var comparers = sync.Map{}
+
+// RegisterComparer registers the compare function for type T, it's used by [SmartCompare] and [Compare]
+// when both values are type T. It's the only way to customize the strict [Compare].
+// The fn should return 0 if a equals b, a negative value if a is less than b,
+// and a positive value if a is greater than b. Such as:
+//
+//	RegisterComparer(func(a, b *big.Int) float64 { return float64(a.Cmp(b)) })
+func RegisterComparer[T any](fn func(a, b T) float64) {
+	comparers.Store(reflect.TypeOf((*T)(nil)).Elem(), func(x, y interface{}) float64 {
+		return fn(x.(T), y.(T))
+	})
+}
+
+// registeredCompare compares x and y via the registered comparer of their type.
+// It returns false if x or y is nil or no comparer is found.
+func registeredCompare(x, y interface{}) (float64, bool) {
+	if reflect.TypeOf(x) != reflect.TypeOf(y) || hasNil(x, y) {
+		return 0, false
+	}
+
+	if fn, has := comparers.Load(reflect.TypeOf(x)); has {
+		return fn.(func(x, y interface{}) float64)(x, y), true
+	}
+
+	return 0, false
+}
+
+// customCompare compares x and y via the registered comparer of their type,
+// or the method of x that accepts y, in the order of "Compare(y) int", "Cmp(y) int", and "Equal(y) bool".
+// It returns false if x or y is nil or no comparer is found.
+func customCompare(x, y interface{}) (float64, bool) {
+	if s, ok := registeredCompare(x, y); ok {
+		return s, true
+	}
+
+	if hasNil(x, y) {
+		return 0, false
+	}
+
+	xVal := reflect.ValueOf(x)
+	yVal := reflect.ValueOf(y)
+
+	for _, name := range []string{"Compare", "Cmp"} {
+		if out, ok := callCompareMethod(xVal, name, yVal, reflect.Int); ok {
+			return float64(out.Int()), true
+		}
+	}
+
+	if out, ok := callCompareMethod(xVal, "Equal", yVal, reflect.Bool); ok {
+		if out.Bool() {
+			return 0, true
+		}
+
+		// the ordering is unknown, but it must be non-zero
+		if s := plainCompare(x, y); s != 0 {
+			return s, true
+		}
+		return 1, true
+	}
+
+	return 0, false
+}
+
+func hasNil(x, y interface{}) bool {
+	_, xNil := IsNil(x)
+	_, yNil := IsNil(y)
+	return xNil || yNil
+}
+
+func callCompareMethod(x reflect.Value, name string, y reflect.Value, out reflect.Kind) (reflect.Value, bool) {
+	m := x.MethodByName(name)
+	if !m.IsValid() || !isCompareFunc(m.Type(), 0, y.Type(), out) {
+		return reflect.Value{}, false
+	}
+
+	return m.Call([]reflect.Value{y})[0], true
+}
+
+// HasCompareMethod returns true if type t defines its own comparison via the method that accepts t,
+// in the form of "Compare(t) int", "Cmp(t) int", or "Equal(t) bool", such as [time.Time] and [*big.Int].
+// The values of such types should be compared as a whole instead of field by field.
+func HasCompareMethod(t reflect.Type) bool {
+	// the method type of a concrete type has the receiver as the first param
+	skip := 1
+	if t.Kind() == reflect.Interface {
+		skip = 0
+	}
+
+	for name, out := range map[string]reflect.Kind{"Compare": reflect.Int, "Cmp": reflect.Int, "Equal": reflect.Bool} {
+		if m, has := t.MethodByName(name); has && isCompareFunc(m.Type, skip, t, out) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompareFunc returns true if the fn accepts y after the skipped params and returns the kind out
+func isCompareFunc(fn reflect.Type, skip int, y reflect.Type, out reflect.Kind) bool {
+	return fn.NumIn() == skip+1 && fn.NumOut() == 1 && y.AssignableTo(fn.In(skip)) && fn.Out(0).Kind() == out
+}
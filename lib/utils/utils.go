@@ -16,6 +16,8 @@ var float64Type = reflect.TypeOf(0.0)
 // the result will be -0.2 . time.Time is also a numerical value.
 // If x or y are not numerical types, both of them will be converted to string format of its value type, the result will be
 // the strings.Compare result between them, such as x is int(1), y is "a", the result will be 1 .
+// The comparer registered via [RegisterComparer] has higher priority than the numerical comparison,
+// the "Compare", "Cmp", or "Equal" method of x that accepts y has higher priority than the string comparison.
 func SmartCompare(x, y interface{}) float64 {
 	_, xNil := IsNil(x)
 	_, yNil := IsNil(y)
@@ -27,6 +29,13 @@ func SmartCompare(x, y interface{}) float64 {
 		return 0
 	}
 
+	if x != nil && y != nil && reflect.TypeOf(x) == reflect.TypeOf(y) {
+		if _, has := comparers.Load(reflect.TypeOf(x)); has {
+			s, _ := customCompare(x, y)
+			return s
+		}
+	}
+
 	if x != nil && y != nil {
 		xVal := reflect.Indirect(reflect.ValueOf(x))
 		yVal := reflect.Indirect(reflect.ValueOf(y))
@@ -42,11 +51,27 @@ func SmartCompare(x, y interface{}) float64 {
 		}
 	}
 
-	return Compare(x, y)
+	if s, ok := customCompare(x, y); ok {
+		return s
+	}
+
+	return plainCompare(x, y)
 }
 
-// Compare returns the float value of x minus y
+// Compare returns the float value of x minus y.
+// It uses the comparer registered via [RegisterComparer],
+// otherwise the strings.Compare result of the string format of x and y.
+// Unlike [SmartCompare], the "Compare", "Cmp", or "Equal" methods are ignored to keep the comparison strict,
+// such as the [time.Time] values of the same instant in different locations are not equal.
 func Compare(x, y interface{}) float64 {
+	if s, ok := registeredCompare(x, y); ok {
+		return s
+	}
+
+	return plainCompare(x, y)
+}
+
+func plainCompare(x, y interface{}) float64 {
 	return float64(strings.Compare(gop.Plain(x), gop.Plain(y)))
 }
 
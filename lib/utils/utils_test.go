@@ -2,6 +2,11 @@ package utils_test
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,6 +61,106 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+type caseInsensitive string
+
+func (s caseInsensitive) Equal(other caseInsensitive) bool {
+	return strings.EqualFold(string(s), string(other))
+}
+
+type alwaysDiff struct{ f float64 }
+
+func (alwaysDiff) Equal(alwaysDiff) bool { return false }
+
+type badCmp struct{ n int }
+
+func (badCmp) Cmp(badCmp) bool { return true }
+
+type version struct{ major, minor int }
+
+func TestCustomCompare(t *testing.T) {
+	utils.RegisterComparer(func(a, b version) float64 {
+		if a.major != b.major {
+			return float64(a.major - b.major)
+		}
+		return float64(a.minor - b.minor)
+	})
+
+	for _, c := range []struct {
+		x interface{}
+		y interface{}
+		s float64
+	}{
+		{version{1, 10}, version{1, 9}, 1},
+		{version{1, 9}, version{2, 0}, -1},
+	} {
+		if s := utils.Compare(c.x, c.y); s != c.s {
+			t.Error("expect compare to be", c.s, "but got", s)
+		}
+	}
+
+	testCases := []struct {
+		x interface{}
+		y interface{}
+		s float64
+	}{
+		{version{1, 10}, version{1, 9}, 1},
+		{version{1, 9}, version{2, 0}, -1},
+		{big.NewInt(10), big.NewInt(9), 1},
+		{big.NewInt(10), big.NewInt(10), 0},
+		{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("9.0.0.1"), 1},
+		{caseInsensitive("A"), caseInsensitive("a"), 0},
+		{caseInsensitive("b"), caseInsensitive("a"), 1},
+		{badCmp{1}, badCmp{2}, -1},
+		{big.NewInt(1), (*big.Int)(nil), -1},
+	}
+	for i, c := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if s := utils.SmartCompare(c.x, c.y); s != c.s {
+				t.Error("expect smart compare to be", c.s, "but got", s)
+			}
+		})
+	}
+
+	// NaN makes them not deeply equal but have the same string format
+	if utils.SmartCompare(alwaysDiff{math.NaN()}, alwaysDiff{math.NaN()}) == 0 {
+		t.Error("the Equal method should be respected")
+	}
+
+	if utils.Compare(caseInsensitive("A"), caseInsensitive("a")) == 0 {
+		t.Error("the strict compare should ignore the Equal method")
+	}
+
+	t0 := time.Unix(0, 0)
+	if utils.Compare(t0, t0.In(time.FixedZone("X", 3600))) == 0 {
+		t.Error("the strict compare should respect the location of time")
+	}
+}
+
+type equaler interface{ Equal(equaler) bool }
+
+type unrelatedEqual struct{ n int }
+
+func (unrelatedEqual) Equal(int) bool { return true }
+
+func TestHasCompareMethod(t *testing.T) {
+	for i, c := range []struct {
+		t   reflect.Type
+		has bool
+	}{
+		{reflect.TypeOf(time.Time{}), true},
+		{reflect.TypeOf(big.NewInt(1)), true},
+		{reflect.TypeOf(caseInsensitive("")), true},
+		{reflect.TypeOf((*equaler)(nil)).Elem(), true},
+		{reflect.TypeOf(badCmp{}), false},
+		{reflect.TypeOf(unrelatedEqual{}), false},
+		{reflect.TypeOf(version{}), false},
+	} {
+		if utils.HasCompareMethod(c.t) != c.has {
+			t.Error(i, "expect has compare method to be", c.has)
+		}
+	}
+}
+
 func TestOthers(t *testing.T) {
 	vs := utils.ToValues([]interface{}{1})
 
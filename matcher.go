@@ -0,0 +1,100 @@
+package got
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ysmood/got/lib/utils"
+)
+
+// Matcher matches dynamic values, such as timestamps or random ids.
+// The String of it is used as the placeholder of the value in the snapshot files.
+type Matcher interface {
+	Match(x interface{}) bool
+	String() string
+}
+
+var (
+	// Any matches any value
+	Any Matcher = matcher{"<any>", func(interface{}) bool { return true }}
+
+	// AnyString matches any string
+	AnyString Matcher = matcher{"<any string>", func(x interface{}) bool {
+		_, ok := x.(string)
+		return ok
+	}}
+
+	// AnyNumber matches any numerical value
+	AnyNumber Matcher = matcher{"<any number>", func(x interface{}) bool {
+		if x == nil {
+			return false
+		}
+		switch reflect.TypeOf(x).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	}}
+
+	// AnyTime matches [time.Time] or string in RFC3339 format
+	AnyTime Matcher = matcher{"<any time>", func(x interface{}) bool {
+		switch v := x.(type) {
+		case time.Time:
+			return true
+		case string:
+			_, err := time.Parse(time.RFC3339Nano, v)
+			return err == nil
+		}
+		return false
+	}}
+)
+
+const matchRegexPrefix = "<regex "
+
+// MatchRegex returns a [Matcher] that matches strings with the regex pattern
+func MatchRegex(pattern string) Matcher {
+	reg := regexp.MustCompile(pattern)
+	return matcher{matchRegexPrefix + pattern + ">", func(x interface{}) bool {
+		s, ok := x.(string)
+		return ok && reg.MatchString(s)
+	}}
+}
+
+// InRange returns a [Matcher] that matches values within [min, max] via [utils.SmartCompare]
+func InRange(min, max interface{}) Matcher {
+	return matcher{fmt.Sprintf("<range %v..%v>", min, max), func(x interface{}) bool {
+		return utils.SmartCompare(x, min) >= 0 && utils.SmartCompare(x, max) <= 0
+	}}
+}
+
+type matcher struct {
+	placeholder string
+	match       func(x interface{}) bool
+}
+
+func (m matcher) Match(x interface{}) bool { return m.match(x) }
+
+func (m matcher) String() string { return m.placeholder }
+
+// parseMatcher returns the matcher of the placeholder, returns nil if s is not a placeholder
+func parseMatcher(s string) Matcher {
+	for _, m := range []Matcher{Any, AnyString, AnyNumber, AnyTime} {
+		if s == m.String() {
+			return m
+		}
+	}
+
+	if strings.HasPrefix(s, matchRegexPrefix) && strings.HasSuffix(s, ">") {
+		pattern := s[len(matchRegexPrefix) : len(s)-1]
+		if _, err := regexp.Compile(pattern); err == nil {
+			return MatchRegex(pattern)
+		}
+	}
+
+	return nil
+}
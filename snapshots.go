@@ -5,75 +5,294 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
-
-	"github.com/ysmood/got/lib/utils"
+	"sync"
 )
 
-const snapshotJSONExt = ".json"
+// SnapshotNewExt is the extension of the pending snapshot update file.
+// When a snapshot mismatches, the new value will be saved to "{SNAPSHOT_FILE}.new" for review.
+const SnapshotNewExt = ".new"
+
+// the working directory when the test binary starts, it's the package dir of the test files
+var initWd, _ = os.Getwd()
+
+type snapshots struct {
+	lock   sync.Mutex
+	format SnapshotFormat
+	root   string
+	list   map[string][]byte
+}
+
+// newSnapshots inherits the format and root from the closest test that has set them, such as the parent test,
+// so the subtests of [Utils.Run], [Each], and [Table] respect the settings of the parent G.
+func newSnapshots(name string) *snapshots {
+	s := &snapshots{
+		format: SnapshotJSON,
+		root:   filepath.Join(initWd, ".got", "snapshots"),
+		list:   map[string][]byte{},
+	}
+
+	if p := snapshotSettings.closest(name); p != nil {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		s.format, s.root = p.format, p.root
+	}
+
+	return s
+}
+
+// snapshotSettings records the snapshots of the tests that have set the format or root, the key is the test name
+var snapshotSettings = &snapshotsRegistry{list: map[string]*snapshots{}}
 
-type snapshot struct {
-	value any
-	used  bool
+type snapshotsRegistry struct {
+	lock sync.Mutex
+	list map[string]*snapshots
 }
 
+// register s for the test, it will be unregistered when the test ends
+func (r *snapshotsRegistry) register(t Testable, s *snapshots) {
+	name := t.Name()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.list[name] == s {
+		return
+	}
+	r.list[name] = s
+
+	t.Cleanup(func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if r.list[name] == s {
+			delete(r.list, name)
+		}
+	})
+}
+
+// closest returns the snapshots of the test or its closest parent, such as "TestA/sub" then "TestA"
+func (r *snapshotsRegistry) closest(name string) *snapshots {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for {
+		if s, has := r.list[name]; has {
+			return s
+		}
+
+		i := strings.LastIndex(name, "/")
+		if i < 0 {
+			return nil
+		}
+		name = name[:i]
+	}
+}
+
+func (s *snapshots) get(path string) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	data, has := s.list[path]
+	return data, has
+}
+
+func (s *snapshots) set(path string, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.list[path] = data
+}
+
+func (s *snapshots) getFormat() SnapshotFormat {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.format
+}
+
+func (s *snapshots) getRoot() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.root
+}
+
+// snapshotsDir mirrors the subtest tree, such as "TestA/sub/case" will be "{ROOT}/TestA/sub/case"
 func (g G) snapshotsDir() string {
-	return filepath.Join(".got", "snapshots", escapeFileName(g.Name()))
+	dir := g.snapshots.getRoot()
+	for _, name := range strings.Split(g.Name(), "/") {
+		name = escapeFileName(name)
+		if name == "" {
+			name = "_"
+		}
+		dir = filepath.Join(dir, name)
+	}
+	return dir
 }
 
-func (g G) loadSnapshots() {
-	paths, err := filepath.Glob(filepath.Join(g.snapshotsDir(), "*"+snapshotJSONExt))
-	g.E(err)
+// snapshotsDirOwners records the test name of each snapshots dir to detect collisions
+var snapshotsDirOwners = sync.Map{}
 
-	for _, path := range paths {
-		g.snapshots.Store(path, snapshot{g.JSON(g.Read(path)), false})
+func (g G) claimSnapshotsDir(dir string) {
+	g.Helper()
+
+	owner, _ := snapshotsDirOwners.LoadOrStore(dir, g.Name())
+	if owner != g.Name() {
+		g.Fatalf("the snapshots dir of test %q collides with test %q: %s", g.Name(), owner, relPath(dir))
 	}
+}
 
+func (g G) loadSnapshot(path string) ([]byte, bool) {
+	g.Helper()
+
+	if data, has := g.snapshots.get(path); has {
+		return data, true
+	}
+
+	if !g.PathExists(path) {
+		return nil, false
+	}
+
+	return g.Read(path).Bytes(), true
+}
+
+func (g G) pruneSnapshots() {
 	g.Cleanup(func() {
 		if g.Failed() {
 			return
 		}
 
-		g.snapshots.Range(func(path, data interface{}) bool {
-			s := data.(snapshot)
-			if !s.used {
-				g.E(os.Remove(path.(string)))
+		dir := g.snapshotsDir()
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+
+		for _, e := range entries {
+			path := filepath.Join(dir, e.Name())
+			if _, used := g.snapshots.get(path); used || e.IsDir() {
+				continue
 			}
-			return true
-		})
+
+			if snapshotStrict() {
+				g.Assertions.err(AssertionSnapshotUnused, relPath(path))
+				continue
+			}
+
+			g.E(os.Remove(path))
+		}
 	})
 }
 
+// SetSnapshotFormat sets the default format of the snapshots for the current test and its subtests,
+// the default is [SnapshotJSON].
+func (g G) SetSnapshotFormat(format SnapshotFormat) {
+	snapshotSettings.register(g, g.snapshots)
+
+	g.snapshots.lock.Lock()
+	defer g.snapshots.lock.Unlock()
+	g.snapshots.format = format
+}
+
+// SetSnapshotsRoot sets the root dir of the snapshots for the current test and its subtests, the default is ".got/snapshots".
+// The relative dir will be resolved against the working directory when the test binary starts,
+// which is the package dir of the test files, so [Utils.Chdir] won't affect it.
+// For example, use "testdata/snapshots" to store the snapshots in the testdata of the package.
+func (g G) SetSnapshotsRoot(dir string) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(initWd, dir)
+	}
+
+	snapshotSettings.register(g, g.snapshots)
+
+	g.snapshots.lock.Lock()
+	defer g.snapshots.lock.Unlock()
+	g.snapshots.root = dir
+}
+
 // Snapshot asserts that x equals the snapshot with the specified name, name should be unique under the same test case.
 // It will create a new snapshot file if the name is not found.
-// The snapshot file will be saved to ".got/snapshots/{TEST_NAME}".
-// To update the snapshot, just change the name of the snapshot or remove the corresponding snapshot file.
+// The snapshot file will be saved to "{ROOT}/{TEST_NAME}/{NAME}{EXT}", the ROOT is set by [G.SetSnapshotsRoot].
+// The subtest "TestA/sub" will be saved to "{ROOT}/TestA/sub".
+// To update the mismatched snapshots, run the test with the "-got.update" flag or the "GOT_UPDATE=1" env,
+// or review the pending updates of the failed test via "go run github.com/ysmood/got/cmd/got snapshot review",
+// or just change the name of the snapshot or remove the corresponding snapshot file.
 // It will auto-remove the unused snapshot files after the test.
 // The snapshot files should be version controlled.
-// The format of the snapshot file is json.
-func (g G) Snapshot(name string, x interface{}) {
+// In the strict mode, which is enabled by the "-got.strict" flag or the "CI=true" env, it will fail the test
+// instead of creating the missing snapshots or removing the unused ones.
+// If an option is [SnapshotFormat], it will be used to encode the snapshot file,
+// the default format is set by [G.SetSnapshotFormat].
+// If an option is [SnapshotRedact], it will be used to redact the dynamic values, such as:
+//
+//	Snapshot("res", res, Redact("$.id"), RedactWith(AnyTime, "$.created_at"))
+//
+// The placeholders of [Matcher] in the snapshot file will be used to match the values structurally,
+// so you can also edit the snapshot file to use placeholders like "<any string>" or "<regex ^\d+$>".
+func (g G) Snapshot(name string, x interface{}, options ...interface{}) {
 	g.Helper()
 
-	path := filepath.Join(g.snapshotsDir(), escapeFileName(name)+snapshotJSONExt)
+	format := g.snapshots.getFormat()
+	redacts := []SnapshotRedact{}
+	for _, item := range options {
+		switch val := item.(type) {
+		case SnapshotFormat:
+			format = val
+		case SnapshotRedact:
+			redacts = append(redacts, val)
+		}
+	}
+
+	data, err := format.Encode(x)
+	g.E(err)
+
+	data, err = redactSnapshot(format, data, redacts)
+	g.E(err)
+
+	dir := g.snapshotsDir()
+	g.claimSnapshotsDir(dir)
+
+	path := filepath.Join(dir, escapeFileName(name)+format.Ext())
+
+	if sData, ok := g.loadSnapshot(path); ok {
+		xVal, err := format.Decode(data)
+		g.E(err)
+		sVal, err := format.Decode(sData)
+		g.E(err)
 
-	if data, ok := g.snapshots.Load(path); ok {
-		s := data.(snapshot)
-		xVal := g.JSON(g.ToJSON(x).Bytes())
-		if utils.SmartCompare(xVal, s.value) == 0 {
-			g.snapshots.Store(path, snapshot{x, true})
+		if matchSnapshot(xVal, sVal) {
+			g.snapshots.set(path, sData)
+			_ = os.Remove(path + SnapshotNewExt)
+		} else if snapshotUpdate() {
+			g.Logf("snapshot updated: %s", relPath(path))
+			_ = os.Remove(path + SnapshotNewExt)
+			g.writeSnapshot(path, data)
 		} else {
-			g.Assertions.err(AssertionEq, xVal, s.value)
+			g.snapshots.set(path, sData)
+			g.writeSnapshot(path+SnapshotNewExt, data)
+			g.Assertions.err(AssertionSnapshot, relPath(path), string(data), string(sData))
 		}
 		return
 	}
 
-	g.snapshots.Store(path, snapshot{x, true})
+	if snapshotStrict() {
+		g.Assertions.err(AssertionSnapshotMissing, relPath(path))
+		return
+	}
+
+	g.writeSnapshot(path, data)
+}
+
+func (g G) writeSnapshot(path string, data []byte) {
+	g.snapshots.set(path, data)
 
 	g.Cleanup(func() {
-		g.E(os.MkdirAll(g.snapshotsDir(), 0755))
-		g.E(os.WriteFile(path, g.ToJSON(x).Bytes(), 0644))
+		g.E(os.MkdirAll(filepath.Dir(path), 0755))
+		g.E(os.WriteFile(path, data, 0644))
 	})
 }
 
+// relPath returns the path relative to the package dir of the test files if it's inside the dir
+func relPath(path string) string {
+	return strings.TrimPrefix(path, initWd+string(filepath.Separator))
+}
+
 func escapeFileName(fileName string) string {
 	// Define the invalid characters for both Windows and Unix
 	invalidChars := `< > : " / \ | ? *`
@@ -0,0 +1,119 @@
+package got
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ysmood/gop"
+)
+
+// SnapshotFormat encodes values to snapshot files and decodes them back for comparison.
+type SnapshotFormat interface {
+	// Ext is the extension of the snapshot file, such as ".json"
+	Ext() string
+
+	// Encode x to the content of the snapshot file
+	Encode(x interface{}) ([]byte, error)
+
+	// Decode the content of the snapshot file to a value that can be compared via [utils.SmartCompare]
+	Decode(data []byte) (interface{}, error)
+}
+
+var (
+	// SnapshotJSON encodes the value as indented json, it's the default format
+	SnapshotJSON SnapshotFormat = snapshotJSON{}
+
+	// SnapshotGop encodes the value as go syntax via [gop.Plain], it keeps the go types of the value
+	SnapshotGop SnapshotFormat = snapshotGop{}
+
+	// SnapshotText saves string, []byte, or [fmt.Stringer] as it is, others will be formatted via [fmt.Sprint]
+	SnapshotText SnapshotFormat = snapshotText{}
+
+	// SnapshotBinary saves []byte or string as it is
+	SnapshotBinary SnapshotFormat = snapshotBinary{}
+)
+
+type snapshotJSON struct{}
+
+func (snapshotJSON) Ext() string { return ".json" }
+
+func (snapshotJSON) Encode(x interface{}) ([]byte, error) {
+	buf, err := encodeJSON(x)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (snapshotJSON) Decode(data []byte) (v interface{}, err error) {
+	err = json.Unmarshal(data, &v)
+	return
+}
+
+type snapshotGop struct{}
+
+func (snapshotGop) Ext() string { return ".gop" }
+
+func (snapshotGop) Encode(x interface{}) ([]byte, error) {
+	return []byte(gop.Plain(x)), nil
+}
+
+func (snapshotGop) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+type snapshotText struct{}
+
+func (snapshotText) Ext() string { return ".txt" }
+
+func (snapshotText) Encode(x interface{}) ([]byte, error) {
+	switch v := x.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	}
+	return []byte(fmt.Sprint(x)), nil
+}
+
+func (snapshotText) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+type snapshotBinary struct{}
+
+func (snapshotBinary) Ext() string { return ".bin" }
+
+func (snapshotBinary) Encode(x interface{}) ([]byte, error) {
+	switch v := x.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, fmt.Errorf("binary snapshot only supports []byte or string, but got %T", x)
+}
+
+func (snapshotBinary) Decode(data []byte) (interface{}, error) {
+	return data, nil
+}
+
+func encodeJSON(obj interface{}) (*bytes.Buffer, error) {
+	buf := bytes.NewBuffer(nil)
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.Truncate(buf.Len() - 1) // Remove the trailing newline
+
+	return buf, nil
+}
@@ -0,0 +1,130 @@
+package got
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InlineSnapshot asserts that x equals the expected snapshot literal in the source code.
+// If the expected is not provided or the snapshot update mode is enabled (see [G.Snapshot]),
+// the caller's source file will be rewritten to embed the encoded x as the expected string literal.
+// The x is encoded by the format set by [G.SetSnapshotFormat].
+// In the strict mode (see [G.Snapshot]), it fails the test instead of rewriting the source when the expected is missing.
+func (g G) InlineSnapshot(x interface{}, expected ...string) {
+	g.Helper()
+
+	sf := g.snapshots.getFormat()
+
+	data, err := sf.Encode(x)
+	g.E(err)
+
+	_, file, line, _ := runtime.Caller(1)
+
+	if len(expected) == 0 && snapshotStrict() {
+		g.Assertions.err(AssertionSnapshotMissing, fmt.Sprintf("%s:%d", file, line))
+		return
+	}
+
+	if len(expected) > 0 {
+		xVal, err := sf.Decode(data)
+		g.E(err)
+		eVal, err := sf.Decode([]byte(expected[0]))
+		g.E(err)
+
+		if matchSnapshot(xVal, eVal) {
+			return
+		}
+
+		if !snapshotUpdate() {
+			g.Assertions.err(AssertionSnapshot, fmt.Sprintf("%s:%d", file, line), string(data), expected[0])
+			return
+		}
+	}
+
+	g.E(rewriteInlineSnapshot(file, line, string(data)))
+	g.Logf("inline snapshot updated: %s:%d", file, line)
+}
+
+var rewriteInlineSnapshot = inlineSnapshots.rewrite
+
+// inlineSnapshotFiles tracks the rewritten source files. Because the line numbers from the
+// [runtime.Caller] always refer to the compiled source, we have to record how many lines are
+// added after each rewrite to locate the following calls in the same file.
+type inlineSnapshotFiles struct {
+	lock   sync.Mutex
+	shifts map[string]map[int]int
+}
+
+var inlineSnapshots = &inlineSnapshotFiles{shifts: map[string]map[int]int{}}
+
+func (fs *inlineSnapshotFiles) rewrite(file string, line int, value string) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	cur := line
+	for l, n := range fs.shifts[file] {
+		if l < line {
+			cur += n
+		}
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	var call *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if call != nil || !ok {
+			return call == nil
+		}
+
+		sel, ok := c.Fun.(*ast.SelectorExpr)
+		if ok && sel.Sel.Name == "InlineSnapshot" && len(c.Args) > 0 &&
+			fset.Position(c.Pos()).Line <= cur && cur <= fset.Position(c.End()).Line {
+			call = c
+		}
+		return true
+	})
+
+	if call == nil {
+		return fmt.Errorf("InlineSnapshot call not found at %s:%d", file, cur)
+	}
+
+	call.Args = []ast.Expr{call.Args[0], &ast.BasicLit{Kind: token.STRING, Value: quoteInlineSnapshot(value)}}
+
+	// the ast is parsed from a valid source, printing it won't fail
+	out := bytes.NewBuffer(nil)
+	_ = format.Node(out, fset, f)
+
+	if fs.shifts[file] == nil {
+		fs.shifts[file] = map[int]int{}
+	}
+	fs.shifts[file][line] += bytes.Count(out.Bytes(), []byte("\n")) - bytes.Count(src, []byte("\n"))
+
+	return os.WriteFile(file, out.Bytes(), 0644)
+}
+
+// use raw string literal for multiline value if possible, it's easier to review
+func quoteInlineSnapshot(value string) string {
+	if strings.Contains(value, "\n") && !strings.ContainsAny(value, "`\r") {
+		return "`" + value + "`"
+	}
+	return strconv.Quote(value)
+}
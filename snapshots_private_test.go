@@ -0,0 +1,65 @@
+package got
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInlineSnapshotRewrite(t *testing.T) {
+	g := New(t)
+
+	path := "tmp/inline/a.go"
+	g.WriteFile(path, `package a
+
+func f(g G) {
+	g.InlineSnapshot(1)
+	g.InlineSnapshot(map[string]int{"a": 1}, "old")
+	g.InlineSnapshot(2) // comment
+	g.InlineSnapshot(
+		3,
+	)
+}
+`)
+
+	fs := &inlineSnapshotFiles{shifts: map[string]map[int]int{}}
+	g.E(fs.rewrite(path, 4, "1"))
+	g.E(fs.rewrite(path, 5, "{\n  \"a\": 1\n}"))
+	g.E(fs.rewrite(path, 6, "`2`"))
+	g.E(fs.rewrite(path, 8, "3"))
+	g.E(fs.rewrite(path, 4, "1"))
+
+	g.Eq(g.Read(path).String(), "package a\n\nfunc f(g G) {\n"+
+		"\tg.InlineSnapshot(1, \"1\")\n"+
+		"\tg.InlineSnapshot(map[string]int{\"a\": 1}, `{\n  \"a\": 1\n}`)\n"+
+		"\tg.InlineSnapshot(2, \"`2`\") // comment\n"+
+		"\tg.InlineSnapshot(\n\t\t3, \"3\",\n\t)\n"+
+		"}\n")
+
+	g.Eq(fs.rewrite(path, 1, "1").Error(), "InlineSnapshot call not found at tmp/inline/a.go:1")
+	g.Err(fs.rewrite("tmp/inline/not-exists.go", 1, "1"))
+
+	g.WriteFile(path, "package")
+	g.Err(fs.rewrite(path, 1, "1"))
+}
+
+func TestInlineSnapshot(t *testing.T) {
+	g := New(t)
+
+	calls := []string{}
+
+	old := rewriteInlineSnapshot
+	rewriteInlineSnapshot = func(file string, _ int, value string) error {
+		g.Eq(filepath.Base(file), "snapshots_private_test.go")
+		calls = append(calls, value)
+		return nil
+	}
+	defer func() { rewriteInlineSnapshot = old }()
+
+	g.InlineSnapshot(1)
+	g.InlineSnapshot("ok", `"ok"`)
+
+	g.Setenv("GOT_UPDATE", "1")
+	g.InlineSnapshot(2, "1")
+
+	g.Eq(calls, []string{"1", "2"})
+}
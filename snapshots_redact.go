@@ -0,0 +1,151 @@
+package got
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ysmood/got/lib/utils"
+)
+
+// SnapshotRedact option for [G.Snapshot]. The values at the paths that match the Matcher
+// will be replaced with the placeholder of the Matcher before saving and comparing.
+// It only works with the [SnapshotJSON] format.
+type SnapshotRedact struct {
+	Matcher Matcher
+	Paths   []string
+}
+
+// Redact the values at the JSONPath-style paths with the [Any] placeholder, such as:
+//
+//	Redact("$.id", "$.items[*].created_at", `$["a.b"]`)
+func Redact(paths ...string) SnapshotRedact {
+	return RedactWith(Any, paths...)
+}
+
+// RedactWith is like [Redact] but only redacts the values that match m with the placeholder of m, such as:
+//
+//	RedactWith(AnyTime, "$.created_at")
+func RedactWith(m Matcher, paths ...string) SnapshotRedact {
+	return SnapshotRedact{m, paths}
+}
+
+func redactSnapshot(format SnapshotFormat, data []byte, redacts []SnapshotRedact) ([]byte, error) {
+	if len(redacts) == 0 {
+		return data, nil
+	}
+
+	if format != SnapshotJSON {
+		return nil, fmt.Errorf("redaction only supports the json snapshot format, but got %s", format.Ext())
+	}
+
+	var v interface{}
+	_ = json.Unmarshal(data, &v) // the data is encoded by json, it won't fail
+
+	for _, r := range redacts {
+		for _, p := range r.Paths {
+			sels, err := parseJSONPath(p)
+			if err != nil {
+				return nil, err
+			}
+			v = redact(v, sels, r.Matcher)
+		}
+	}
+
+	buf, _ := encodeJSON(v) // the v is decoded from json, it won't fail
+	return buf.Bytes(), nil
+}
+
+var regJSONPathSel = regexp.MustCompile(`^(?:\.([^.\[\]]+)|\[(\d+|\*|"(?:[^"\\]|\\.)*")\])`)
+
+// parseJSONPath parses path like `$.a[0].b[*]["c.d"]` to selectors, the "*" selects all.
+func parseJSONPath(path string) ([]string, error) {
+	if len(path) == 0 || path[0] != '$' {
+		return nil, fmt.Errorf("json path should start with $: %s", path)
+	}
+
+	sels := []string{}
+	rest := path[1:]
+	for rest != "" {
+		ms := regJSONPathSel.FindStringSubmatch(rest)
+		if ms == nil {
+			return nil, fmt.Errorf("invalid json path: %s", path)
+		}
+		rest = rest[len(ms[0]):]
+
+		sel := ms[1] + ms[2]
+		if sel[0] == '"' {
+			sel, _ = strconv.Unquote(sel)
+		}
+		sels = append(sels, sel)
+	}
+	return sels, nil
+}
+
+func redact(v interface{}, sels []string, m Matcher) interface{} {
+	if len(sels) == 0 {
+		if m.Match(v) {
+			return m.String()
+		}
+		return v
+	}
+
+	sel := sels[0]
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if sel == "*" || sel == k {
+				val[k] = redact(item, sels[1:], m)
+			}
+		}
+	case []interface{}:
+		for i, item := range val {
+			if sel == "*" || sel == strconv.Itoa(i) {
+				val[i] = redact(item, sels[1:], m)
+			}
+		}
+	}
+
+	return v
+}
+
+// matchSnapshot compares x with the snapshot value s structurally,
+// the placeholders of [Matcher] in s will be used to match the values in x.
+func matchSnapshot(x, s interface{}) bool {
+	if utils.SmartCompare(x, s) == 0 {
+		return true
+	}
+
+	switch sv := s.(type) {
+	case string:
+		if m := parseMatcher(sv); m != nil {
+			return m.Match(x)
+		}
+	case map[string]interface{}:
+		xv, ok := x.(map[string]interface{})
+		if !ok || len(xv) != len(sv) {
+			return false
+		}
+		for k, item := range sv {
+			if xItem, has := xv[k]; !has || !matchSnapshot(xItem, item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		xv, ok := x.([]interface{})
+		if !ok || len(xv) != len(sv) {
+			return false
+		}
+		for i, item := range sv {
+			if !matchSnapshot(xv[i], item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
@@ -1,15 +1,20 @@
 package got_test
 
 import (
+	"flag"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ysmood/gop"
 	"github.com/ysmood/got"
 )
 
 func TestSnapshots(t *testing.T) {
+	t.Setenv("CI", "false")
+
 	g := got.T(t)
 
 	type C struct {
@@ -29,7 +34,14 @@ func TestSnapshots(t *testing.T) {
 	gm := got.New(m)
 	gm.Snapshot("a", "ok")
 	gm.Snapshot("a", "no")
-	m.check(`"no" ⦗not ==⦘ "ok"`)
+	m.check(`
+⦗snapshot⦘ ` + filepath.FromSlash(".got/snapshots/TestSnapshots/a.json") + ` ⦗mismatch⦘
+
+@@ diff chunk @@
+1   - "ok"
+  1 + "no"
+
+⦗run the test with the "-got.update" flag or the "GOT_UPDATE=1" env to update it⦘`)
 
 	gm.Snapshot("a", map[int]int{1: 2})
 	g.Has(m.msg, "diff chunk")
@@ -37,10 +49,14 @@ func TestSnapshots(t *testing.T) {
 
 	gm.ErrorHandler = got.NewDefaultAssertionError(15, gop.ThemeNone, nil)
 	gm.Snapshot("a", "no")
-	m.checkWithStyle(true, `"no" ⦗not ==⦘ "ok"`)
+	m.checkWithStyle(true, ` ⦗snapshot⦘ `+filepath.FromSlash(".got/snapshots/TestSnapshots/a.json")+
+		` ⦗mismatch⦘ "no" ⦗not ==⦘ "ok" `+
+		`⦗run the test with the "-got.update" flag or the "GOT_UPDATE=1" env to update it⦘ `)
 }
 
 func TestSnapshotsCreate(t *testing.T) {
+	t.Setenv("CI", "false")
+
 	path := filepath.FromSlash(".got/snapshots/TestSnapshotsCreate/a.json")
 	err := os.RemoveAll(path)
 	if err != nil {
@@ -57,6 +73,8 @@ func TestSnapshotsCreate(t *testing.T) {
 }
 
 func TestSnapshotsNotUsed(t *testing.T) {
+	t.Setenv("CI", "false")
+
 	path := filepath.FromSlash(".got/snapshots/TestSnapshotsNotUsed/a.json")
 
 	g := got.T(t)
@@ -70,6 +88,8 @@ func TestSnapshotsNotUsed(t *testing.T) {
 }
 
 func TestSnapshotsNotUsedWhenFailure(t *testing.T) {
+	t.Setenv("CI", "false")
+
 	path := filepath.FromSlash(".got/snapshots/TestSnapshotsNotUsedWhenFailure/a.json")
 
 	g := got.T(t)
@@ -82,3 +102,349 @@ func TestSnapshotsNotUsedWhenFailure(t *testing.T) {
 
 	g.True(g.PathExists(path))
 }
+
+func TestSnapshotsUpdate(t *testing.T) {
+	path := filepath.FromSlash(".got/snapshots/TestSnapshotsUpdate/a.json")
+
+	g := got.T(t)
+	g.WriteFile(path, []byte(`"ok"`))
+
+	g.Setenv("GOT_UPDATE", "1")
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.Snapshot("a", "no")
+	m.cleanup()
+
+	g.False(m.failed)
+	g.Eq(m.msg, "snapshot updated: "+path)
+	g.Eq(g.Read(path).String(), `"no"`)
+}
+
+func TestSnapshotsUpdateFlag(t *testing.T) {
+	path := filepath.FromSlash(".got/snapshots/TestSnapshotsUpdateFlag/a.json")
+
+	g := got.T(t)
+	g.WriteFile(path, []byte(`"ok"`))
+
+	g.E(flag.Set("got.update", "true"))
+	defer func() { g.E(flag.Set("got.update", "false")) }()
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.Snapshot("a", "no")
+	m.cleanup()
+
+	g.False(m.failed)
+	g.Eq(g.Read(path).String(), `"no"`)
+}
+
+func TestSnapshotsFormat(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	g := got.T(t)
+
+	type C struct {
+		Time time.Time
+		Val  int64
+		Data []byte
+	}
+
+	c := C{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), math.MaxInt64, []byte("ok")}
+
+	g.Cleanup(func() {
+		g.Eq(g.Read(".got/snapshots/TestSnapshotsFormat/text-stringer.txt").String(), "1s")
+	})
+
+	g.Snapshot("gop", c, got.SnapshotGop)
+	g.Snapshot("gop", c, got.SnapshotGop)
+	g.Snapshot("text", "ok", got.SnapshotText)
+	g.Snapshot("text-bytes", []byte("ok"), got.SnapshotText)
+	g.Snapshot("text-stringer", time.Second, got.SnapshotText)
+	g.Snapshot("text-any", 1, got.SnapshotText)
+	g.Snapshot("binary", []byte{0, 1}, got.SnapshotBinary)
+	g.Snapshot("binary-str", "ok", got.SnapshotBinary)
+	g.Snapshot("text", "ok", got.SnapshotText)
+	g.Snapshot("binary", []byte{0, 1}, got.SnapshotBinary)
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(15, gop.ThemeNone, nil)
+
+	gm.SetSnapshotFormat(got.SnapshotGop)
+	gm.Snapshot("gop", c)
+	c.Val = 1
+	gm.Snapshot("gop", c)
+	g.Has(m.msg, "not ==")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Snapshot("err", 1, got.SnapshotBinary)
+	})
+	g.Has(m.msg, "binary snapshot only supports []byte or string, but got int")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Snapshot("err", make(chan int), got.SnapshotJSON)
+	})
+	g.Has(m.msg, "UnsupportedTypeError")
+}
+
+func TestInlineSnapshotErr(t *testing.T) {
+	// the update mode would rewrite the mismatched snapshot below
+	t.Setenv("GOT_UPDATE", "")
+
+	g := got.T(t)
+
+	m := &mock{t: t}
+	gm := got.New(m)
+	gm.InlineSnapshot(2, "1")
+	g.Has(m.msg, "snapshots_test.go:")
+	g.Has(gop.StripANSI(m.msg), "1   - 1\n  1 + 2")
+	m.reset()
+
+	g.Panic(func() {
+		gm.InlineSnapshot(make(chan int))
+	})
+	g.Has(m.msg, "UnsupportedTypeError")
+	m.reset()
+
+	g.Panic(func() {
+		gm.InlineSnapshot(1, "{")
+	})
+	g.Has(m.msg, "SyntaxError")
+}
+
+func TestSnapshotsRedact(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	g := got.T(t)
+
+	res := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":         g.RandStr(8),
+			"created_at": time.Now(),
+			"items": []interface{}{
+				map[string]interface{}{"id": g.RandInt(0, 100), "name": "a"},
+				map[string]interface{}{"id": g.RandInt(0, 100), "name": "b"},
+			},
+			"a.b":  g.RandStr(4),
+			"code": "x" + g.RandStr(3),
+		}
+	}
+
+	opts := []interface{}{
+		got.RedactWith(got.AnyString, "$.id"),
+		got.RedactWith(got.AnyTime, "$.created_at"),
+		got.RedactWith(got.AnyNumber, "$.items[*].id"),
+		got.Redact(`$["a.b"]`),
+		got.RedactWith(got.MatchRegex(`^x`), "$.code"),
+	}
+
+	g.Cleanup(func() {
+		g.Eq(g.JSON(g.Read(filepath.FromSlash(".got/snapshots/TestSnapshotsRedact/res.json"))), map[string]interface{}{
+			"id":         "<any string>",
+			"created_at": "<any time>",
+			"items": []interface{}{
+				map[string]interface{}{"id": "<any number>", "name": "a"},
+				map[string]interface{}{"id": "<any number>", "name": "b"},
+			},
+			"a.b":  "<any>",
+			"code": "<regex ^x>",
+		})
+	})
+
+	g.Snapshot("res", res(), opts...)
+	g.Snapshot("res", res(), opts...)
+
+	g.WriteFile(filepath.FromSlash(".got/snapshots/TestSnapshotsRedact/placeholders.json"),
+		`["a", "<any number>", "<any time>", "<regex ^x$>", {"a": "<any>"}, "<regex (>"]`)
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.ErrorHandler = got.NewDefaultAssertionError(15, gop.ThemeNone, nil)
+
+	gm.Snapshot("placeholders", []interface{}{"a", 1, time.Now(), "x", map[string]interface{}{"a": 1}, "<regex (>"})
+	g.False(m.failed)
+
+	gm.Snapshot("res", res(), opts...)
+	r := res()
+	r["code"] = "y"
+	gm.Snapshot("res", r, opts...)
+	g.Has(m.msg, `"code": "y"`)
+	m.reset()
+
+	for _, x := range []interface{}{
+		[]interface{}{"a", 1, time.Now(), "x"},
+		[]interface{}{"a", 1, time.Now(), "x", map[string]interface{}{"a": 1, "b": 2}, "<regex (>"},
+		[]interface{}{"a", 1, time.Now(), "x", map[string]interface{}{"b": 1}, "<regex (>"},
+		[]interface{}{"a", 1, time.Now(), "x", 1, "<regex (>"},
+		map[string]interface{}{},
+	} {
+		gm.Snapshot("placeholders", x)
+		g.True(m.failed)
+		m.reset()
+	}
+
+	g.Panic(func() {
+		gm.Snapshot("err", 1, got.SnapshotGop, got.Redact("$.a"))
+	})
+	g.Has(m.msg, "redaction only supports the json snapshot format, but got .gop")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Snapshot("err", 1, got.Redact("a"))
+	})
+	g.Has(m.msg, "json path should start with $: a")
+	m.reset()
+
+	g.Panic(func() {
+		gm.Snapshot("err", 1, got.Redact("$.a["))
+	})
+	g.Has(m.msg, "invalid json path: $.a[")
+}
+
+func TestMatchers(t *testing.T) {
+	g := got.T(t)
+
+	g.True(got.Any.Match(nil))
+	g.False(got.AnyString.Match(1))
+	g.True(got.AnyNumber.Match(uint8(1)))
+	g.False(got.AnyNumber.Match("1"))
+	g.False(got.AnyNumber.Match(nil))
+	g.True(got.AnyTime.Match(time.Now()))
+	g.False(got.AnyTime.Match("1"))
+	g.False(got.AnyTime.Match(1))
+	g.False(got.MatchRegex(`a`).Match(1))
+	g.Eq(got.MatchRegex(`\d`).String(), `<regex \d>`)
+}
+
+func TestSnapshotsLayout(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	g := got.T(t)
+
+	g.Cleanup(func() {
+		g.True(g.PathExists(filepath.FromSlash(".got/snapshots/TestSnapshotsLayout/sub/case/a.json")))
+		g.True(g.PathExists(filepath.FromSlash(".got/snapshots/TestSnapshotsLayout/_/a.json")))
+		g.True(g.PathExists(filepath.FromSlash(".got/snapshots/TestSnapshotsLayout/chdir.json")))
+	})
+
+	g.Run("sub", func(g got.G) {
+		g.Run("case", func(g got.G) {
+			g.Snapshot("a", 1)
+		})
+	})
+
+	g.MkdirAll(0, "tmp/chdir")
+	g.Chdir("tmp/chdir")
+	g.Snapshot("chdir", 1)
+
+	m := &mock{t: t, name: t.Name() + "/.."}
+	got.New(m).Snapshot("a", 1)
+	m.cleanup()
+
+	m = &mock{t: t, name: t.Name() + "/x:y"}
+	got.New(m).Snapshot("a", 1)
+	m = &mock{t: t, name: t.Name() + "/x_y"}
+	g.Panic(func() {
+		got.New(m).Snapshot("a", 1)
+	})
+	m.check(`the snapshots dir of test "TestSnapshotsLayout/x_y" collides with test "TestSnapshotsLayout/x:y": ` +
+		filepath.FromSlash(".got/snapshots/TestSnapshotsLayout/x_y"))
+}
+
+func TestSnapshotsRoot(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	g := got.T(t)
+
+	wd, err := os.Getwd()
+	g.E(err)
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.SetSnapshotsRoot("tmp/snapshots")
+	gm.Snapshot("a", 1)
+	gm.SetSnapshotsRoot(filepath.Join(wd, "tmp", "abs"))
+	gm.Snapshot("a", 1)
+	m.cleanup()
+
+	g.True(g.PathExists(filepath.FromSlash("tmp/snapshots/TestSnapshotsRoot/a.json")))
+	g.True(g.PathExists(filepath.FromSlash("tmp/abs/TestSnapshotsRoot/a.json")))
+	g.E(os.RemoveAll("tmp"))
+}
+
+func TestSnapshotsInherit(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	g := got.T(t)
+	t.Cleanup(func() { g.E(os.RemoveAll("tmp-inherit")) })
+
+	g.SetSnapshotsRoot("tmp-inherit")
+	g.SetSnapshotFormat(got.SnapshotGop)
+
+	g.Run("sub", func(g got.G) {
+		g.Snapshot("a", 1)
+
+		g.Run("nested", func(g got.G) {
+			g.SetSnapshotFormat(got.SnapshotJSON)
+			g.Snapshot("a", 1)
+		})
+	})
+
+	got.Table(t, []string{"case"}, func(g got.G, c string) {
+		g.Snapshot("a", c)
+	})
+
+	g.True(g.PathExists(filepath.FromSlash("tmp-inherit/TestSnapshotsInherit/sub/a.gop")))
+	g.True(g.PathExists(filepath.FromSlash("tmp-inherit/TestSnapshotsInherit/sub/nested/a.json")))
+	g.True(g.PathExists(filepath.FromSlash("tmp-inherit/TestSnapshotsInherit/case/a.gop")))
+}
+
+func TestSnapshotsPending(t *testing.T) {
+	t.Setenv("CI", "false")
+
+	path := filepath.FromSlash(".got/snapshots/TestSnapshotsPending/a.json")
+
+	g := got.T(t)
+	g.WriteFile(path, []byte(`"ok"`))
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.Snapshot("a", "no")
+	m.cleanup()
+	g.True(m.failed)
+	g.Eq(g.Read(path+got.SnapshotNewExt).String(), `"no"`)
+
+	m = &mock{t: t, name: t.Name()}
+	gm = got.New(m)
+	gm.Snapshot("a", "ok")
+	m.cleanup()
+	g.False(m.failed)
+	g.False(g.PathExists(path + got.SnapshotNewExt))
+}
+
+func TestSnapshotsStrict(t *testing.T) {
+	path := filepath.FromSlash(".got/snapshots/TestSnapshotsStrict/a.json")
+
+	t.Setenv("CI", "true")
+
+	g := got.T(t)
+	g.WriteFile(path, []byte(`1`))
+
+	m := &mock{t: t, name: t.Name()}
+	gm := got.New(m)
+	gm.Snapshot("b", 1)
+	m.check(" ⦗snapshot⦘ " + filepath.FromSlash(".got/snapshots/TestSnapshotsStrict/b.json") +
+		` ⦗doesn't exist, run the test with the "-got.update" flag to create it⦘ `)
+
+	m.cleanup()
+	m.check(" ⦗snapshot⦘ " + path + " ⦗is unused, remove it or run the test without the strict mode⦘ ")
+	g.True(g.PathExists(path))
+
+	gm.InlineSnapshot(1)
+	g.True(m.failed)
+	g.Has(m.msg, "snapshots_test.go:")
+	g.Has(m.msg, `doesn't exist, run the test with the "-got.update" flag to create it`)
+}
@@ -0,0 +1,111 @@
+package got
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// Table runs fn with each case as a sub test of t, such as:
+//
+//	got.Table(t, []struct {
+//		Name     string
+//		In, Out  int
+//		Parallel bool
+//	}{
+//		{Name: "one", In: 1, Out: 2},
+//		{Name: "two", In: 2, Out: 3, Parallel: true},
+//	}, func(g got.G, c struct{...}) {
+//		g.Eq(inc(c.In), c.Out)
+//	})
+//
+// The name of the sub test is the Name field of the case if it's a non-empty string, or the "%v" format of the case.
+// The bool fields Only, Skip, and Parallel of the case work like the markers [Only], [Skip], and [Concurrent] of [Each].
+// Like [Each], the order of the cases can be randomized by the "-got.shuffle" flag or the "GOT_SHUFFLE" env.
+func Table[T any](t Testable, cases []T, fn func(g G, c T)) (count int) {
+	t.Helper()
+
+	names := make([]string, len(cases))
+	for i, c := range cases {
+		names[i] = tableCaseName(c)
+	}
+
+	// copy the cases to not shuffle the original ones
+	return runTable(t, names, append([]T{}, cases...), fn)
+}
+
+// TableMap is like [Table] but the name of the sub test is the key of the case, the cases run in the order of the keys.
+func TableMap[T any](t Testable, cases map[string]T, fn func(g G, c T)) (count int) {
+	t.Helper()
+
+	names := make([]string, 0, len(cases))
+	for name := range cases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]T, len(names))
+	for i, name := range names {
+		list[i] = cases[name]
+	}
+
+	return runTable(t, names, list, fn)
+}
+
+func runTable[T any](t Testable, names []string, cases []T, fn func(g G, c T)) int {
+	t.Helper()
+
+	shuffle(t, len(cases), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+		cases[i], cases[j] = cases[j], cases[i]
+	})
+
+	only := false
+	for _, c := range cases {
+		only = only || tableCaseFlag(c, "Only")
+	}
+
+	// the parallel cases count themselves before they pause, so it's safe to read after the loop
+	var n int64
+
+	for i, c := range cases {
+		c := c
+		if only && !tableCaseFlag(c, "Only") {
+			continue
+		}
+
+		Utils{t}.Run(names[i], func(g G) {
+			if tableCaseFlag(c, "Skip") {
+				g.SkipNow()
+			}
+			atomic.AddInt64(&n, 1)
+			if tableCaseFlag(c, "Parallel") {
+				tryParallel(g.Testable)
+			}
+			fn(g, c)
+		})
+	}
+
+	return int(atomic.LoadInt64(&n))
+}
+
+func tableCaseName(c interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(c))
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Name"); f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return fmt.Sprintf("%v", c)
+}
+
+// tableCaseFlag returns the value of the bool field of the case, it returns false if the field doesn't exist
+func tableCaseFlag(c interface{}, name string) bool {
+	v := reflect.Indirect(reflect.ValueOf(c))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	f := v.FieldByName(name)
+	return f.Kind() == reflect.Bool && f.Bool()
+}
@@ -0,0 +1,95 @@
+package got_test
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ysmood/got"
+)
+
+func TestTable(t *testing.T) {
+	g := got.New(t)
+
+	type tc struct {
+		Name    string
+		In, Out int
+	}
+
+	count := got.Table(t, []tc{
+		{Name: "one", In: 1, Out: 2},
+		{In: 2, Out: 3},
+	}, func(g got.G, c tc) {
+		g.Eq(c.In+1, c.Out)
+	})
+	g.Eq(count, 2)
+
+	count = got.TableMap(t, map[string]int{"a": 1, "b": 2}, func(g got.G, c int) {
+		g.Gt(c, 0)
+	})
+	g.Eq(count, 2)
+}
+
+func TestTableNames(t *testing.T) {
+	g := got.New(t)
+	t.Setenv("GOT_SHUFFLE", "off")
+
+	type tc struct {
+		Name string
+		Val  int
+	}
+
+	names := []string{}
+	got.Table(t, []interface{}{tc{Name: "a"}, &tc{Val: 1}, 2}, func(g got.G, _ interface{}) {
+		names = append(names, path.Base(g.Name()))
+	})
+	g.Eq(names, []string{"a", "&{_1}", "2"})
+
+	names = []string{}
+	got.TableMap(t, map[string]bool{"d": true, "c": false}, func(g got.G, _ bool) {
+		names = append(names, path.Base(g.Name()))
+	})
+	g.Eq(names, []string{"c", "d"})
+}
+
+func TestTableFlags(t *testing.T) {
+	g := got.New(t)
+	t.Setenv("GOT_SHUFFLE", "off")
+
+	type tc struct {
+		Val        int
+		Only, Skip bool
+	}
+
+	vals := []int{}
+	count := got.Table(t, []tc{{Val: 1}, {Val: 2, Only: true}, {Val: 3, Only: true, Skip: true}}, func(_ got.G, c tc) {
+		vals = append(vals, c.Val)
+	})
+	g.Eq(count, 1)
+	g.Eq(vals, []int{2})
+}
+
+func TestTableParallel(t *testing.T) {
+	g := got.New(t)
+
+	type tc struct {
+		Parallel bool
+		Close    bool
+	}
+
+	wait := make(chan struct{})
+
+	// the first case will timeout if the cases are not run concurrently
+	count := got.Table(t, []tc{{Parallel: true}, {Parallel: true, Close: true}}, func(g got.G, c tc) {
+		if c.Close {
+			close(wait)
+			return
+		}
+		select {
+		case <-wait:
+		case <-g.Timeout(time.Second).Done():
+			g.Fatal("not concurrent")
+		}
+	})
+	g.Eq(count, 2)
+}
@@ -133,18 +133,26 @@ func (ut Utils) PanicAfter(d time.Duration) (cancel func()) {
 
 // Context that will be canceled after the test
 func (ut Utils) Context() Context {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ut.parentContext())
 	ut.Cleanup(cancel)
 	return Context{ctx, cancel}
 }
 
 // Timeout context that will be canceled after the test
 func (ut Utils) Timeout(d time.Duration) Context {
-	ctx, cancel := context.WithTimeout(context.Background(), d)
+	ctx, cancel := context.WithTimeout(ut.parentContext(), d)
 	ut.Cleanup(cancel)
 	return Context{ctx, cancel}
 }
 
+// parentContext is canceled at the deadline of the attempt of [Assertions.Eventually] or [Assertions.Consistently]
+func (ut Utils) parentContext() context.Context {
+	if a, ok := ut.Testable.(*attempt); ok {
+		return a.ctx
+	}
+	return context.Background()
+}
+
 // RandStr generates a random string with the specified length
 func (ut Utils) RandStr(l int) string {
 	ut.Helper()
@@ -305,17 +313,8 @@ func (ut Utils) JSON(src interface{}) (v interface{}) {
 func (ut Utils) ToJSON(obj interface{}) *bytes.Buffer {
 	ut.Helper()
 
-	buf := bytes.NewBuffer(nil)
-
-	enc := json.NewEncoder(buf)
-	enc.SetEscapeHTML(false)
-	enc.SetIndent("", "  ")
-
-	err := enc.Encode(obj)
+	buf, err := encodeJSON(obj)
 	ut.err(err)
-
-	buf.Truncate(buf.Len() - 1) // Remove the trailing newline
-
 	return buf
 }
 
@@ -25,3 +25,11 @@ func TestPanicAfter(t *testing.T) {
 	time.Sleep(time.Millisecond)
 	<-wait
 }
+
+func TestCallSourceNotFound(t *testing.T) {
+	g := New(t)
+
+	source, args := callSource("not-exists.go", 1, "Eq")
+	g.Eq(source, "")
+	g.Len(args, 0)
+}